@@ -0,0 +1,694 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// Коды завершения неинтерактивного режима
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitNotInstalled    = 3
+	ExitUpdateAvailable = 4
+	ExitMaintenance     = 5
+	ExitLocked          = 6
+	ExitNetwork         = 7
+	ExitInvalidInstall  = 8
+	ExitVersionMismatch = 9
+	ExitLauncherBlocked = 10
+	ExitCancelled       = 130
+)
+
+// cliOptions - флаги, общие для всех подкоманд
+type cliOptions struct {
+	expectVersion string
+	target        string
+	force         bool
+	json          bool
+}
+
+// cliEnv - окружение, в котором выполняется подкоманда
+type cliEnv struct {
+	launcherPath string
+	gameDirPath  string
+	opts         cliOptions
+	stdout       io.Writer
+	stderr       io.Writer
+	progress     func(InstallProgress)
+}
+
+// cliCommand описывает подкоманду лаунчера
+type cliCommand struct {
+	name        string
+	description string
+	withForce   bool
+	withTarget  bool
+	document    bool // В режиме --json команда выводит один документ, а не поток событий
+	run         func(ctx context.Context, env *cliEnv) int
+}
+
+var cliCommands = []cliCommand{
+	{name: "install", description: "cli.cmd.install", withForce: true, run: cliInstall},
+	{name: "update", description: "cli.cmd.update", withForce: true, run: cliUpdate},
+	{name: "verify", description: "cli.cmd.verify", run: cliVerify},
+	{name: "launch", description: "cli.cmd.launch", run: cliLaunch},
+	{name: "status", description: "cli.cmd.status", document: true, run: cliStatus},
+	{name: "self-update", description: "cli.cmd.self_update", run: cliSelfUpdate},
+	{name: "move", description: "cli.cmd.move", withTarget: true, run: cliMove},
+	{name: "clean", description: "cli.cmd.clean", run: cliClean},
+}
+
+// RunCLI выполняет подкоманду лаунчера без TUI и возвращает код завершения
+func RunCLI(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printCLIUsage(os.Stdout)
+		return ExitOK
+	}
+
+	var command *cliCommand
+	for i := range cliCommands {
+		if cliCommands[i].name == args[0] {
+			command = &cliCommands[i]
+		}
+	}
+	if command == nil {
+		fmt.Fprintf(os.Stderr, "%s\n\n", T("cli.unknown_command", args[0]))
+		printCLIUsage(os.Stderr)
+		return ExitUsage
+	}
+
+	env := &cliEnv{stdout: os.Stdout, stderr: os.Stderr}
+	flags := flag.NewFlagSet(command.name, flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	RegisterSettingsFlags(flags)
+	flags.StringVar(&env.opts.expectVersion, "expect-version", "", T("cli.flag.expect_version"))
+	flags.BoolVar(&env.opts.json, "json", false, T("cli.flag.json"))
+	if command.withForce {
+		flags.BoolVar(&env.opts.force, "force", false, T("cli.flag.force"))
+	}
+	if command.withTarget {
+		flags.StringVar(&env.opts.target, "to", "", T("cli.flag.to"))
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, T("cli.extra_args", strings.Join(flags.Args(), " ")))
+		return ExitUsage
+	}
+
+	if err := LoadSettings(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return ExitUsage
+	}
+
+	launcherPath, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, T("launcher.executable_path_error", err))
+		return ExitError
+	}
+	env.launcherPath = launcherPath
+	env.gameDirPath = GetGameDirPath(launcherPath)
+	if env.opts.json {
+		env.progress = env.emitProgress
+		// Сообщения общих функций лаунчера тоже превращаем в события
+		messageSink = func(level, message string) {
+			env.emit(cliEvent{Event: "message", Level: level, Text: message})
+		}
+		gameStdout = os.Stderr
+		defer func() {
+			messageSink = nil
+			gameStdout = os.Stdout
+		}()
+	} else {
+		env.progress = env.printProgress()
+	}
+
+	if err := OpenLauncherLog(GetLogDirPath(env.gameDirPath)); err == nil {
+		defer CloseLauncherLog()
+	}
+	LogLauncher("Команда: %s", strings.Join(args, " "))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	code := command.run(ctx, env)
+	if command.document {
+		return code
+	}
+	return env.finish(code)
+}
+
+func printCLIUsage(w io.Writer) {
+	fmt.Fprintln(w, T("cli.usage"))
+	for _, command := range cliCommands {
+		fmt.Fprintf(w, "  %-12s %s\n", command.name, T(command.description))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, T("cli.usage_flags"))
+	for _, field := range settingFields {
+		fmt.Fprintf(w, "  --%-17s %s: %s (%s)\n", field.flag, T(field.title), T(field.hint), field.env)
+	}
+}
+
+// printProgress возвращает обработчик прогресса, печатающий строку при изменении процента
+func (env *cliEnv) printProgress() func(InstallProgress) {
+	lastPercent := -1
+	return func(progress InstallProgress) {
+		percent := 0
+		if progress.Total > 0 {
+			percent = progress.Current * 100 / progress.Total
+		}
+		if percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		fmt.Fprintf(env.stdout, "[%3d%%] %s\n", percent, progress.Message)
+	}
+}
+
+// cliEvent - событие в формате NDJSON для режима --json
+type cliEvent struct {
+	Event string `json:"event"`
+	*InstallProgress
+	Level string `json:"level,omitempty"`
+	Text  string `json:"text,omitempty"`
+	Code  *int   `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+	// MessageID - идентификатор сообщения в каталоге, не зависящий от языка
+	MessageID string `json:"message_id,omitempty"`
+}
+
+func (env *cliEnv) emit(event cliEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(env.stdout, string(data))
+}
+
+func (env *cliEnv) emitProgress(progress InstallProgress) {
+	env.emit(cliEvent{Event: "progress", InstallProgress: &progress})
+}
+
+// info сообщает о ходе выполнения команды сообщением из каталога
+func (env *cliEnv) info(id string, args ...interface{}) {
+	message := T(id, args...)
+	if env.opts.json {
+		env.emit(cliEvent{Event: "message", Level: Info, Text: message, MessageID: eventMessageID(id, args)})
+		return
+	}
+	fmt.Fprintln(env.stdout, message)
+}
+
+// warn сообщает о проблеме, которая не прерывает команду
+func (env *cliEnv) warn(id string, args ...interface{}) {
+	message := T(id, args...)
+	if env.opts.json {
+		env.emit(cliEvent{Event: "message", Level: Warn, Text: message, MessageID: eventMessageID(id, args)})
+		return
+	}
+	fmt.Fprintln(env.stderr, message)
+}
+
+// fail сообщает об ошибке и возвращает код завершения
+func (env *cliEnv) fail(code int, id string, args ...interface{}) int {
+	message := T(id, args...)
+	if env.opts.json {
+		env.emit(cliEvent{Event: "error", Code: &code, Error: message, MessageID: eventMessageID(id, args)})
+		return code
+	}
+	fmt.Fprintln(env.stderr, message)
+	return code
+}
+
+// eventMessageID возвращает идентификатор сообщения для события. Ошибка, выводимая
+// без пояснений, передает идентификатор своего сообщения
+func eventMessageID(id string, args []interface{}) string {
+	if id == "cli.error" && len(args) == 1 {
+		if err, ok := args[0].(error); ok {
+			return messageID(err)
+		}
+		return ""
+	}
+	return id
+}
+
+// finish завершает поток событий итоговым кодом
+func (env *cliEnv) finish(code int) int {
+	if env.opts.json {
+		env.emit(cliEvent{Event: "result", Code: &code})
+	}
+	return code
+}
+
+// fetchManifest получает манифест и сообщает об ошибке
+func (env *cliEnv) fetchManifest() (*ManifestDto, int) {
+	manifest, err := GetRemoteManifest()
+	if err != nil {
+		return nil, env.fail(ExitNetwork, "launcher.manifest_failed", err)
+	}
+	if code := env.checkLauncherBlock(manifest, true); code != ExitOK {
+		return manifest, code
+	}
+	if IsLauncherUpdateMandatory(manifest) {
+		return manifest, env.fail(ExitUpdateAvailable, "cli.launcher_update_required", LauncherVersion, manifest.Version.MinLauncher)
+	}
+	// --expect-version только сверяет версию: лаунчер ставит ту версию, что раздает сервер
+	if env.opts.expectVersion != "" && manifest.Version.Game != env.opts.expectVersion {
+		return manifest, env.fail(ExitVersionMismatch, "cli.server_version_mismatch", manifest.Version.Game, env.opts.expectVersion)
+	}
+	return manifest, ExitOK
+}
+
+// checkLauncherBlock проверяет, не заблокирована ли версия лаунчера манифестом. Заблокированная
+// версия работает только с командой self-update, а при gameFiles - еще и не меняет файлы игры
+func (env *cliEnv) checkLauncherBlock(manifest *ManifestDto, gameFiles bool) int {
+	block := LauncherBlockFor(manifest)
+	if block == nil {
+		return ExitOK
+	}
+	if block.Action == BlockActionForceUpdate || gameFiles && GameUpdatesBlocked(manifest) {
+		return env.fail(ExitLauncherBlocked, "cli.launcher_blocked", block.Text())
+	}
+	env.warn("cli.error", block.Text())
+	return ExitOK
+}
+
+// acquireLock захватывает блокировку директории игры или объясняет, кто ее держит
+func (env *cliEnv) acquireLock() (*InstanceLock, int) {
+	lock, holder, err := AcquireInstanceLock(env.gameDirPath)
+	if errors.Is(err, ErrInstanceLocked) {
+		return nil, env.fail(ExitLocked, "cli.error", describeLockHolder(holder))
+	}
+	if err != nil {
+		return nil, env.fail(ExitError, "launcher.lock_failed", err)
+	}
+	if err := RecoverInstall(env.gameDirPath, env.launcherPath); err != nil {
+		env.warn("launcher.recover_failed", err)
+	}
+	return lock, ExitOK
+}
+
+// runInstallOperation выполняет установку или обновление тем же кодом, что и TUI
+func (env *cliEnv) runInstallOperation(ctx context.Context, operation string, manifest *ManifestDto) int {
+	progressChan := make(chan InstallProgress, 100)
+	done := make(chan error, 1)
+	go func() {
+		defer close(progressChan)
+		done <- installGameWithProgress(ctx, env.gameDirPath, env.launcherPath, operation, manifest, progressChan)
+	}()
+	for progress := range progressChan {
+		env.progress(progress)
+	}
+
+	err := <-done
+	switch {
+	case err == nil:
+		env.progress(InstallProgress{Current: 100, Total: 100, Message: T("cli.done")})
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return env.fail(ExitCancelled, "cli.cancelled")
+	case errors.Is(err, ErrInvalidInstall):
+		return env.fail(ExitInvalidInstall, "cli.error", err)
+	default:
+		return env.fail(ExitError, "cli.error", err)
+	}
+}
+
+func cliInstall(ctx context.Context, env *cliEnv) int {
+	lock, code := env.acquireLock()
+	if code != ExitOK {
+		return code
+	}
+	defer lock.Release()
+
+	manifest, code := env.fetchManifest()
+	if code != ExitOK {
+		return code
+	}
+	state, err := DetectGameState(env.gameDirPath, manifest)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if state.Installed && !state.NeedsUpdate && !env.opts.force {
+		env.info("cli.already_installed", state.LocalVersion)
+		return ExitOK
+	}
+	if state.Installed && !IsGameAccessible(manifest) {
+		return env.fail(ExitMaintenance, "launcher.maintenance_blocked")
+	}
+	if lock.IsGameRunning() {
+		return env.fail(ExitLocked, "launcher.game_running")
+	}
+
+	if err := createGameDirectory(env.gameDirPath); err != nil {
+		return env.fail(ExitError, "cli.create_dir_failed", err)
+	}
+	return env.runInstallOperation(ctx, "install", manifest)
+}
+
+func cliUpdate(ctx context.Context, env *cliEnv) int {
+	lock, code := env.acquireLock()
+	if code != ExitOK {
+		return code
+	}
+	defer lock.Release()
+
+	manifest, code := env.fetchManifest()
+	if code != ExitOK {
+		return code
+	}
+	state, err := DetectGameState(env.gameDirPath, manifest)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if !state.Installed {
+		return env.fail(ExitNotInstalled, "cli.not_installed")
+	}
+	if !state.NeedsUpdate && !env.opts.force {
+		env.info("cli.up_to_date", state.LocalVersion)
+		return ExitOK
+	}
+	if !IsGameAccessible(manifest) {
+		return env.fail(ExitMaintenance, "launcher.maintenance_blocked")
+	}
+	if lock.IsGameRunning() {
+		return env.fail(ExitLocked, "launcher.game_running")
+	}
+
+	return env.runInstallOperation(ctx, "update", manifest)
+}
+
+func cliVerify(ctx context.Context, env *cliEnv) int {
+	if HasIncompleteInstall(env.gameDirPath) {
+		return env.fail(ExitInvalidInstall, "cli.install_incomplete")
+	}
+	state, err := DetectGameState(env.gameDirPath, nil)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if !state.Installed {
+		return env.fail(ExitNotInstalled, "cli.not_installed")
+	}
+	if err := ValidateGameInstall(env.gameDirPath, nil); err != nil {
+		return env.fail(ExitInvalidInstall, "cli.error", err)
+	}
+	env.info("cli.verify_ok", state.LocalVersion)
+
+	if env.opts.expectVersion != "" {
+		if state.LocalVersion != env.opts.expectVersion {
+			return env.fail(ExitVersionMismatch, "cli.version_mismatch", state.LocalVersion, env.opts.expectVersion)
+		}
+		return ExitOK
+	}
+
+	manifest, err := GetRemoteManifest()
+	if err != nil {
+		env.warn("cli.update_check_failed", err)
+		return ExitOK
+	}
+	if state, err = DetectGameState(env.gameDirPath, manifest); err == nil && state.NeedsUpdate {
+		return env.fail(ExitUpdateAvailable, "cli.update_available", state.LocalVersion, manifest.Version.Game)
+	}
+	return ExitOK
+}
+
+func cliLaunch(ctx context.Context, env *cliEnv) int {
+	lock, code := env.acquireLock()
+	if code != ExitOK {
+		return code
+	}
+	defer lock.Release()
+
+	state, err := DetectGameState(env.gameDirPath, nil)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if !state.Installed {
+		return env.fail(ExitNotInstalled, "cli.not_installed")
+	}
+	if lock.IsGameRunning() {
+		return env.fail(ExitLocked, "cli.already_running")
+	}
+
+	if manifest, err := GetRemoteManifest(); err != nil {
+		env.warn("cli.server_check_failed", err)
+	} else {
+		if !IsGameAccessible(manifest) {
+			return env.fail(ExitMaintenance, "launcher.maintenance_blocked")
+		}
+		if code := env.checkLauncherBlock(manifest, false); code != ExitOK {
+			return code
+		}
+		if IsLauncherUpdateMandatory(manifest) {
+			return env.fail(ExitUpdateAvailable, "cli.launcher_update_required", LauncherVersion, manifest.Version.MinLauncher)
+		}
+		if IsGameUpdateMandatory(state.LocalVersion, manifest) {
+			return env.fail(ExitUpdateAvailable, "cli.game_update_required", state.LocalVersion, manifest.Version.MinGame)
+		}
+		if state, err := DetectGameState(env.gameDirPath, manifest); err == nil && state.NeedsUpdate {
+			env.warn("cli.launch_outdated", manifest.Version.Game, state.LocalVersion)
+		}
+	}
+
+	if err := TryRunGame(env.gameDirPath, lock); err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	return ExitOK
+}
+
+// statusNotice - уведомление сервера в JSON-статусе
+type statusNotice struct {
+	Text  string `json:"text"`
+	Level string `json:"level"`
+}
+
+// statusDocument - ответ команды status в режиме --json
+type statusDocument struct {
+	GameDir             string             `json:"game_dir"`
+	Installed           bool               `json:"installed"`
+	LocalVersion        string             `json:"local_version,omitempty"`
+	LauncherVersion     string             `json:"launcher_version"`
+	RemoteGameVersion   string             `json:"remote_game_version,omitempty"`
+	RemoteLauncher      string             `json:"remote_launcher_version,omitempty"`
+	NeedsUpdate         bool               `json:"needs_update"`
+	NeedsLauncherUpdate bool               `json:"needs_launcher_update"`
+	UpdateMandatory     bool               `json:"update_mandatory"`          // Версия игры ниже min_game
+	LauncherMandatory   bool               `json:"launcher_update_mandatory"` // Версия лаунчера ниже min_launcher
+	LauncherBlock       *BlockedLauncher   `json:"launcher_block,omitempty"`  // Запись blocked_launchers для этой версии
+	GameRollout         bool               `json:"game_rollout"`              // Установка получает игру из постепенного выпуска
+	LauncherRollout     bool               `json:"launcher_rollout"`          // То же для лаунчера
+	GameAccessible      bool               `json:"game_accessible"`
+	Maintenance         *statusNotice      `json:"maintenance,omitempty"`
+	ServerMessage       *statusNotice      `json:"server_message,omitempty"`
+	Manifest            *ManifestDto       `json:"manifest,omitempty"`
+	ManifestCache       *ManifestCacheInfo `json:"manifest_cache,omitempty"`
+	ManifestCacheError  string             `json:"manifest_cache_error,omitempty"`
+	ClockOffsetSeconds  int64              `json:"clock_offset_seconds"` // Насколько часы сервера впереди
+	Error               string             `json:"error,omitempty"`
+	ErrorID             string             `json:"error_id,omitempty"`
+}
+
+// buildStatusDocument собирает состояние игры и сервера
+func (env *cliEnv) buildStatusDocument() (statusDocument, int) {
+	doc := statusDocument{
+		GameDir:         env.gameDirPath,
+		LauncherVersion: LauncherVersion,
+		GameAccessible:  true,
+	}
+
+	state, err := DetectGameState(env.gameDirPath, nil)
+	if err != nil {
+		doc.Error, doc.ErrorID = err.Error(), messageID(err)
+		return doc, ExitError
+	}
+	doc.Installed = state.Installed
+	doc.LocalVersion = state.LocalVersion
+
+	manifest, fetchErr := GetRemoteManifest()
+	// Кэш читается после запроса: успешный ответ сервера обновляет его
+	if doc.ManifestCache, err = GetManifestCacheInfo(); err != nil {
+		doc.ManifestCacheError = err.Error()
+	}
+	if fetchErr != nil {
+		doc.Error, doc.ErrorID = fetchErr.Error(), messageID(fetchErr)
+		return doc, ExitNetwork
+	}
+	doc.ClockOffsetSeconds = int64(ClockOffset().Round(time.Second) / time.Second)
+	if state, err = DetectGameState(env.gameDirPath, manifest); err != nil {
+		doc.Error, doc.ErrorID = err.Error(), messageID(err)
+		return doc, ExitError
+	}
+
+	doc.Manifest = manifest
+	doc.RemoteGameVersion = manifest.Version.Game
+	doc.RemoteLauncher = manifest.Version.Launcher
+	doc.NeedsUpdate = state.NeedsUpdate
+	doc.NeedsLauncherUpdate = NeedsLauncherUpdate(manifest)
+	doc.UpdateMandatory = state.Installed && IsGameUpdateMandatory(state.LocalVersion, manifest)
+	doc.LauncherMandatory = IsLauncherUpdateMandatory(manifest)
+	doc.LauncherBlock = LauncherBlockFor(manifest)
+	doc.GameRollout = InRollout(UpdateKindGame)
+	doc.LauncherRollout = InRollout(UpdateKindLauncher)
+	doc.GameAccessible = IsGameAccessible(manifest)
+	if text, level := GetMaintenanceMessage(manifest); text != "" {
+		doc.Maintenance = &statusNotice{Text: text, Level: level}
+	}
+	if text, level := GetServerMessage(manifest); text != "" {
+		doc.ServerMessage = &statusNotice{Text: text, Level: level}
+	}
+	return doc, ExitOK
+}
+
+func cliStatus(ctx context.Context, env *cliEnv) int {
+	doc, code := env.buildStatusDocument()
+
+	if env.opts.json {
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			fmt.Fprintln(env.stderr, err)
+			return ExitError
+		}
+		fmt.Fprintln(env.stdout, string(data))
+		return code
+	}
+
+	fmt.Fprintln(env.stdout, T("cli.status.game_dir", doc.GameDir))
+	if doc.Installed {
+		fmt.Fprintln(env.stdout, T("cli.status.installed", doc.LocalVersion))
+	} else {
+		fmt.Fprintln(env.stdout, T("cli.status.not_installed"))
+	}
+	fmt.Fprintln(env.stdout, T("cli.status.launcher", doc.LauncherVersion))
+	if doc.ManifestCache != nil {
+		fmt.Fprintln(env.stdout, T("cli.status.manifest_cache", doc.ManifestCache.FetchedAt.Local().Format("2006-01-02 15:04")))
+	} else {
+		fmt.Fprintln(env.stdout, T("cli.status.no_manifest_cache", doc.ManifestCacheError))
+	}
+	if doc.Manifest == nil {
+		fmt.Fprintln(env.stderr, T("launcher.manifest_failed", doc.Error))
+		return code
+	}
+
+	fmt.Fprintln(env.stdout, T("cli.status.remote_game", doc.RemoteGameVersion))
+	fmt.Fprintln(env.stdout, T("cli.status.remote_launcher", doc.RemoteLauncher))
+	if doc.NeedsUpdate {
+		fmt.Fprintln(env.stdout, T("cli.status.update_required"))
+	} else {
+		fmt.Fprintln(env.stdout, T("cli.status.update_not_required"))
+	}
+	if warning := ClockSkewWarning(); warning != "" {
+		fmt.Fprintln(env.stdout, T("cli.status.clock_skew", warning))
+	}
+	if doc.Maintenance != nil {
+		fmt.Fprintln(env.stdout, T("cli.status.maintenance", doc.Maintenance.Text))
+	} else {
+		fmt.Fprintln(env.stdout, T("cli.status.no_maintenance"))
+	}
+	if doc.ServerMessage != nil {
+		fmt.Fprintln(env.stdout, T("cli.status.server_message", doc.ServerMessage.Text))
+	}
+	return code
+}
+
+func cliSelfUpdate(ctx context.Context, env *cliEnv) int {
+	manifest, err := GetRemoteManifest()
+	if err != nil {
+		return env.fail(ExitNetwork, "launcher.manifest_failed", err)
+	}
+	if !NeedsLauncherUpdate(manifest) {
+		env.info("cli.launcher_up_to_date", LauncherVersion)
+		return ExitOK
+	}
+
+	env.info("cli.self_updating", LauncherVersion, manifest.Version.Launcher)
+	// Лаунчер заменит скрипт после выхода: RunCLI запишет итог и завершится
+	if err := UpdateLauncher(env.launcherPath, false); err != nil {
+		return env.fail(ExitError, "launcher.update_failed", err)
+	}
+	return ExitOK
+}
+
+func cliMove(ctx context.Context, env *cliEnv) int {
+	if env.opts.target == "" {
+		return env.fail(ExitUsage, "cli.move_target_required")
+	}
+	// В папке только для чтения блокировку не создать: игра копируется без нее, старые файлы остаются
+	var lock *InstanceLock
+	if err := CheckGameDirWritable(env.gameDirPath); err != nil {
+		env.warn("cli.move_read_only", err)
+	} else {
+		var code int
+		lock, code = env.acquireLock()
+		if code != ExitOK {
+			return code
+		}
+	}
+	defer func() { lock.Release() }()
+
+	state, err := DetectGameState(env.gameDirPath, nil)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if !state.Installed {
+		return env.fail(ExitNotInstalled, "cli.not_installed")
+	}
+	dst, err := ResolveMoveTarget(env.gameDirPath, env.opts.target)
+	if err != nil {
+		return env.fail(ExitUsage, "cli.error", err)
+	}
+
+	env.info("cli.moving", env.gameDirPath, dst)
+	progressChan := make(chan InstallProgress, 100)
+	go func() {
+		defer close(progressChan)
+		lock, err = MoveGame(ctx, lock, env.gameDirPath, dst, env.launcherPath, progressChan)
+	}()
+	for progress := range progressChan {
+		env.progress(progress)
+	}
+
+	switch {
+	case err == nil:
+		env.info("cli.moved", dst)
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return env.fail(ExitCancelled, "cli.move_cancelled")
+	case errors.Is(err, ErrInvalidInstall):
+		return env.fail(ExitInvalidInstall, "cli.error", err)
+	default:
+		return env.fail(ExitError, "cli.error", err)
+	}
+}
+
+func cliClean(ctx context.Context, env *cliEnv) int {
+	lock, code := env.acquireLock()
+	if code != ExitOK {
+		return code
+	}
+	defer lock.Release()
+
+	removed := CleanupLeftovers(env.launcherPath, env.gameDirPath)
+	if len(removed) == 0 {
+		env.info("cli.nothing_to_clean")
+		return ExitOK
+	}
+	for _, entry := range removed {
+		env.info("cli.removed", entry)
+	}
+	return ExitOK
+}
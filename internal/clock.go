@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// clockSkewWarnThreshold - расхождение часов, о котором стоит предупредить пользователя
+const clockSkewWarnThreshold = 5 * time.Minute
+
+var (
+	clockMu     sync.Mutex
+	clockOffset time.Duration // Насколько часы сервера впереди часов компьютера
+)
+
+// ServerNow возвращает текущее время по часам сервера. До первого ответа сервера
+// совпадает с часами компьютера
+func ServerNow() time.Time {
+	return time.Now().UTC().Add(ClockOffset())
+}
+
+// ClockOffset возвращает оценку расхождения часов: положительная - часы компьютера отстают
+func ClockOffset() time.Duration {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	return clockOffset
+}
+
+// updateClockOffset оценивает расхождение часов по ответу сервера. Берется поле server_time
+// свежего манифеста, а если его нет - заголовок Date. Считается, что сервер записал время
+// посередине между отправкой запроса и получением ответа
+func updateClockOffset(resp *http.Response, manifest *ManifestDto, sent, received time.Time) {
+	var serverTime time.Time
+	if manifest != nil && manifest.ServerTime != nil {
+		serverTime = manifest.ServerTime.Time
+	} else if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		serverTime = date
+	} else {
+		return
+	}
+
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	offset := serverTime.Sub(midpoint)
+	// Date указывается с точностью до секунды, меньшие расхождения - погрешность измерения
+	if offset > -time.Second && offset < time.Second {
+		offset = 0
+	}
+
+	clockMu.Lock()
+	changed := offset.Round(time.Second) != clockOffset.Round(time.Second)
+	clockOffset = offset
+	clockMu.Unlock()
+
+	if changed {
+		LogLauncher("Часы: расхождение с сервером %v", offset.Round(time.Second))
+	}
+}
+
+// clockSkew возвращает идентификатор предупреждения и величину расхождения, если часы
+// компьютера сильно расходятся с часами сервера, иначе пустой идентификатор
+func clockSkew() (string, time.Duration) {
+	offset := ClockOffset()
+	switch {
+	case offset >= clockSkewWarnThreshold:
+		return "clock.behind", offset
+	case offset <= -clockSkewWarnThreshold:
+		return "clock.ahead", -offset
+	}
+	return "", 0
+}
+
+// ClockSkewWarning возвращает предупреждение о расхождении часов или пустую строку
+func ClockSkewWarning() string {
+	id, skew := clockSkew()
+	if id == "" {
+		return ""
+	}
+	return T(id, formatCountdown(skew))
+}
@@ -1,6 +1,15 @@
 package internal
 
-import "runtime"
+import (
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// DefaultChannel - основной канал обновлений
+const DefaultChannel = "stable"
+
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
 
 type GameExecutables struct {
 	Windows string
@@ -65,7 +74,49 @@ var (
 	}
 )
 
+// baseManifestURL - манифест основного канала, от него строятся адреса остальных каналов
+var baseManifestURL = RemoteManifestURL
+
+// currentChannel - канал обновлений, выбранный через ApplyChannel. Пусто - основной канал
+var currentChannel string
+
+// ApplyChannel переключает лаунчер на манифест указанного канала обновлений.
+// Манифест канала лежит рядом с основным: launcher-manifest-<канал>.yaml
+func ApplyChannel(channel string) error {
+	if channel == "" || channel == DefaultChannel {
+		RemoteManifestURL = baseManifestURL
+		currentChannel = ""
+		return nil
+	}
+	if !channelNamePattern.MatchString(channel) {
+		return newError("err.settings.invalid_channel", channel)
+	}
+	RemoteManifestURL = strings.TrimSuffix(baseManifestURL, ".yaml") + "-" + channel + ".yaml"
+	currentChannel = channel
+	return nil
+}
+
+// channelURL возвращает адрес файла выбранного канала. Файлы канала лежат в подпапке с его
+// названием рядом с манифестом основного канала (.../submarine-game/beta/windows/submarine.zip).
+// Для основного канала и адресов с другого сервера адрес не меняется
+func channelURL(fileURL string) string {
+	if currentChannel == "" {
+		return fileURL
+	}
+	baseDir := baseManifestURL[:strings.LastIndex(baseManifestURL, "/")+1]
+	if !strings.HasPrefix(fileURL, baseDir) {
+		return fileURL
+	}
+	return baseDir + currentChannel + "/" + strings.TrimPrefix(fileURL, baseDir)
+}
+
+// GetLauncherURL возвращает адрес лаунчера для текущей платформы и канала. Если установка
+// попала в постепенный выпуск, файл берется из подпапки с номером версии
 func GetLauncherURL() string {
+	return rolloutURL(channelURL(launcherURLForPlatform()), UpdateKindLauncher)
+}
+
+func launcherURLForPlatform() string {
 	switch runtime.GOOS {
 	case "windows":
 		return LauncherURLs.Windows
@@ -78,7 +129,12 @@ func GetLauncherURL() string {
 	}
 }
 
+// GetArchiveURL возвращает адрес архива игры, как и GetLauncherURL, с учетом канала и выпуска
 func GetArchiveURL() string {
+	return rolloutURL(channelURL(archiveURLForPlatform()), UpdateKindGame)
+}
+
+func archiveURLForPlatform() string {
 	switch runtime.GOOS {
 	case "windows":
 		return ArchiveURLs.Windows
@@ -94,7 +150,12 @@ func GetArchiveURL() string {
 	}
 }
 
+// GetHashURL возвращает адрес контрольной суммы архива игры той же версии, что и GetArchiveURL
 func GetHashURL() string {
+	return rolloutURL(channelURL(hashURLForPlatform()), UpdateKindGame)
+}
+
+func hashURLForPlatform() string {
 	switch runtime.GOOS {
 	case "windows":
 		return HashURLs.Windows
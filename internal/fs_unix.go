@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package internal
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isCrossDeviceError проверяет, что файл нельзя переименовать, потому что папки на разных дисках
+func isCrossDeviceError(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
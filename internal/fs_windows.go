@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package internal
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorNotSameDevice - ERROR_NOT_SAME_DEVICE, перенос файла на другой диск
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDeviceError проверяет, что файл нельзя переименовать, потому что папки на разных дисках
+func isCrossDeviceError(err error) bool {
+	return errors.Is(err, errorNotSameDevice) || errors.Is(err, syscall.EXDEV)
+}
@@ -7,11 +7,16 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 )
 
-func runExecution(path string, logWriter io.Writer) error {
-	cmd := exec.Command(path, "-launcher")
+// gameStdout - куда дублируется стандартный вывод игры
+var gameStdout io.Writer = os.Stdout
+
+func runExecution(path string, logWriter io.Writer, onStart func(pid int)) error {
+	args := append([]string{"-launcher"}, strings.Fields(activeSettings.LaunchArgs)...)
+	cmd := exec.Command(path, args...)
 
 	// Создаем pipes для перехвата stdout и stderr
 	stdout, err := cmd.StdoutPipe()
@@ -27,9 +32,12 @@ func runExecution(path string, logWriter io.Writer) error {
 	if err := cmd.Start(); err != nil {
 		return err
 	}
+	if onStart != nil {
+		onStart(cmd.Process.Pid)
+	}
 
 	// Создаем multiwriter для записи в лог и stdout
-	stdoutWriter := io.MultiWriter(logWriter, os.Stdout)
+	stdoutWriter := io.MultiWriter(logWriter, gameStdout)
 	stderrWriter := io.MultiWriter(logWriter, os.Stderr)
 
 	// Копируем вывод в горутинах
@@ -40,9 +48,10 @@ func runExecution(path string, logWriter io.Writer) error {
 	return cmd.Wait()
 }
 
-// TryRunGame пытается запустить игру и ждет её завершения
-func TryRunGame(dataDir string) error {
-	fmt.Print("Запуск игры...\n")
+// TryRunGame пытается запустить игру и ждет её завершения.
+// PID игры записывается в блокировку, чтобы другие экземпляры не обновляли запущенную игру
+func TryRunGame(dataDir string, lock *InstanceLock) error {
+	fmt.Fprintln(gameStdout, T("game.starting"))
 
 	// Получаем имя исполняемого файла для текущей платформы
 	gameFile := GetExecutableForPlatform()
@@ -51,40 +60,45 @@ func TryRunGame(dataDir string) error {
 
 	// Проверяем существование файла
 	if _, err := os.Stat(gamePath); err != nil {
-		ShowStyledMessage(Error, fmt.Sprintf("Исполняемый файл игры не найден: %s", gamePath))
-		return fmt.Errorf("исполняемый файл игры не найден: %s", gamePath)
+		ShowStyledMessage(Error, T("game.executable_not_found", gamePath))
+		return newError("err.game.executable_not_found", gamePath)
 	}
 
 	// На macOS удаляем подпись кода
 	if runtime.GOOS == "darwin" {
 		codesignCmd := exec.Command("codesign", "--remove-signature", gamePath)
 		if err := codesignCmd.Run(); err != nil {
-			ShowStyledMessage(Warn, "Не удалось удалить подпись кода: "+err.Error())
+			ShowStyledMessage(Warn, T("game.codesign_failed", err))
 		} else {
-			ShowStyledMessage(Info, "Подпись кода удалена")
+			ShowStyledMessage(Info, T("game.codesign_removed"))
 		}
 	}
 
 	// На Unix-системах устанавливаем права на выполнение
 	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
 		if err := os.Chmod(gamePath, 0755); err != nil {
-			ShowStyledMessage(Error, "Ошибка при установке прав на выполнение: "+err.Error())
+			ShowStyledMessage(Error, T("game.chmod_failed", err))
 			return err
 		}
 		if _, err := os.Stat(ttsPath); err == nil {
 			if err := os.Chmod(ttsPath, 0755); err != nil {
-				ShowStyledMessage(Warn, "Не удалось установить права на выполнение TTS: "+err.Error())
+				ShowStyledMessage(Warn, T("game.tts_chmod_failed", err))
 			}
 		} else if !os.IsNotExist(err) {
-			ShowStyledMessage(Warn, "Ошибка при проверке TTS: "+err.Error())
+			ShowStyledMessage(Warn, T("game.tts_check_failed", err))
 		}
 	}
 
 	// Создаем лог-файл с текущей датой и временем
-	logDir := filepath.Join(filepath.Dir(dataDir), "logs")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		ShowStyledMessage(Warn, "Не удалось создать папку для логов: "+err.Error())
-		logDir = filepath.Dir(dataDir) // Используем родительскую папку
+	logDir := GetLogDirPath(dataDir)
+	if err := os.MkdirAll(logDir, 0755); err != nil || checkDirWritable(logDir) != nil {
+		// Папка логов недоступна для записи: пишем во временную папку системы
+		fallbackDir := filepath.Join(os.TempDir(), LauncherConfigDirName, "logs")
+		ShowStyledMessage(Warn, T("game.log_dir_fallback", logDir, fallbackDir))
+		logDir = fallbackDir
+		os.MkdirAll(logDir, 0755)
+	} else {
+		pruneGameLogs(logDir, activeSettings.LogRetention)
 	}
 
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
@@ -93,49 +107,93 @@ func TryRunGame(dataDir string) error {
 
 	logFile, err := os.Create(logPath)
 	if err != nil {
-		ShowStyledMessage(Warn, "Не удалось создать лог-файл: "+err.Error())
+		ShowStyledMessage(Warn, T("game.log_create_failed", err))
 		// Продолжаем без логирования
 		logFile = nil
 	} else {
 		defer logFile.Close()
 		// Записываем заголовок в лог
-		fmt.Fprintf(logFile, "=== Лог игры начат: %s ===\n", time.Now().Format("2006-01-02 15:04:05"))
-		fmt.Fprintf(logFile, "Путь к игре: %s\n", gamePath)
+		fmt.Fprintln(logFile, T("game.log_started", time.Now().Format("2006-01-02 15:04:05")))
+		fmt.Fprintln(logFile, T("game.log_path", gamePath))
 		fmt.Fprintf(logFile, "============================\n\n")
 	}
 
-	var logger = CreateLogger(os.Stdout)
+	// Без файла лога вывод идет туда же, куда и вывод игры: в режиме --json это stderr
+	var logger = CreateLogger(gameStdout)
 	if logFile != nil {
 		logger.writer = logFile
-		ShowStyledMessage(Info, fmt.Sprintf("Лог игры записывается в: %s", logPath))
+		ShowStyledMessage(Info, T("game.log_location", logPath))
 	}
 
-	ShowStyledMessage(Info, fmt.Sprintf("Игра запущена: %s", filepath.Base(gamePath)))
+	ShowStyledMessage(Info, T("game.started", filepath.Base(gamePath)))
 
-	err = runExecution(gamePath, logger)
+	err = runExecution(gamePath, logger, lock.SetGamePID)
+	lock.SetGamePID(0)
 
 	if logFile != nil {
 		logger.FlushRepeat()
 		fmt.Fprintf(logFile, "\n============================\n")
-		fmt.Fprintf(logFile, "=== Лог игры завершен: %s ===\n", time.Now().Format("2006-01-02 15:04:05"))
+		fmt.Fprintln(logFile, T("game.log_finished", time.Now().Format("2006-01-02 15:04:05")))
 	}
 
 	if err != nil {
-		ShowStyledMessage(Error, "Игра завершилась с ошибкой: "+err.Error())
+		ShowStyledMessage(Error, T("game.exited_with_error", err))
 		return err
 	}
 
-	ShowStyledMessage(Info, "Игра завершена")
+	ShowStyledMessage(Info, T("game.exited"))
 	return nil
 }
 
+// GameState - состояние установленной игры относительно манифеста
+type GameState struct {
+	Installed    bool   // Игра установлена полностью
+	LocalVersion string // Установленная версия
+	NeedsUpdate  bool   // На сервере доступна более новая версия
+}
+
+// DetectGameState определяет, установлена ли игра и нужно ли ее обновить.
+// manifest может быть nil, если сервер недоступен
+func DetectGameState(gameDirPath string, manifest *ManifestDto) (GameState, error) {
+	var state GameState
+
+	versionPath := filepath.Join(gameDirPath, GameVersionFileName)
+	if _, err := os.Stat(versionPath); os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return state, newError("err.game.version_check", err)
+	}
+
+	// Игра в середине замены файлов не считается установленной
+	if HasIncompleteInstall(gameDirPath) {
+		return state, nil
+	}
+
+	localVersion, err := GetGameLocalVersion(versionPath)
+	if err != nil {
+		return state, newError("err.game.version_read", err)
+	}
+	state.Installed = true
+	state.LocalVersion = localVersion
+
+	if manifest != nil {
+		// Используем семантическое сравнение версий
+		isNewer, err := IsVersionNewer(localVersion, manifest.Version.Game)
+		if err != nil {
+			// Если не удалось сравнить версии семантически, используем строковое сравнение
+			LogLauncher("Не удалось сравнить версии семантически: %v", err)
+			state.NeedsUpdate = localVersion != manifest.Version.Game
+		} else {
+			state.NeedsUpdate = isNewer
+		}
+	}
+	return state, nil
+}
+
+// GetGameDirPath возвращает папку игры: из настроек, если она задана, иначе рядом с лаунчером
 func GetGameDirPath(launcherPath string) string {
-	launcherDirPath := filepath.Dir(launcherPath)
-	var gameDirPath string
-	if filepath.Base(launcherDirPath) == GameFolderName {
-		gameDirPath = launcherDirPath
-	} else {
-		gameDirPath = filepath.Join(launcherDirPath, GameFolderName)
+	if activeSettings.GameDir != "" {
+		return activeSettings.GameDir
 	}
-	return gameDirPath
+	return defaultGameDirPath(launcherPath)
 }
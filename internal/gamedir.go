@@ -0,0 +1,409 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultGameDirPath возвращает папку игры по умолчанию: на Linux - в $XDG_DATA_HOME,
+// в портативном режиме и на других системах - рядом с лаунчером
+func defaultGameDirPath(launcherPath string) string {
+	if useXDGLayout() {
+		if dir := xdgGameDirPath(launcherPath); dir != "" {
+			return dir
+		}
+	}
+	return legacyGameDirPath(launcherPath)
+}
+
+// legacyGameDirPath возвращает папку игры рядом с лаунчером: саму папку лаунчера,
+// если она называется SubmarineGame, или подпапку SubmarineGame
+func legacyGameDirPath(launcherPath string) string {
+	launcherDirPath := filepath.Dir(launcherPath)
+	if filepath.Base(launcherDirPath) == GameFolderName {
+		return launcherDirPath
+	}
+	return filepath.Join(launcherDirPath, GameFolderName)
+}
+
+// IsGameDirOverridden сообщает, что папка игры задана переменной окружения или флагом
+// и выбирать ее в интерфейсе бессмысленно
+func IsGameDirOverridden() bool {
+	return settingOverrideSource("game_dir") != ""
+}
+
+// RememberGameDir сохраняет папку игры в файле настроек. Папка по умолчанию не записывается,
+// чтобы она по-прежнему вычислялась при запуске
+func RememberGameDir(launcherPath, dir string) error {
+	settings := fileSettings
+	if dir == defaultGameDirPath(launcherPath) {
+		settings.GameDir = ""
+	} else {
+		settings.GameDir = dir
+	}
+	return SaveSettings(settings)
+}
+
+// ResolveInstallDir проверяет папку, выбранную для установки игры, и возвращает итоговый путь.
+// В непустую папку без игры игра ставится в подпапку SubmarineGame: при установке
+// содержимое папки игры заменяется, и чужие файлы были бы удалены
+func ResolveInstallDir(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", newError("err.dir.not_specified")
+	}
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", newError("err.dir.invalid_path", err)
+	}
+
+	empty, err := isGameDirFree(dir)
+	if err != nil {
+		return "", err
+	}
+	if !empty && !hasGameInstall(dir) && filepath.Base(dir) != GameFolderName {
+		dir = filepath.Join(dir, GameFolderName)
+		if empty, err = isGameDirFree(dir); err != nil {
+			return "", err
+		}
+	}
+	if !empty && !hasGameInstall(dir) {
+		return "", newError("err.dir.not_empty", dir)
+	}
+
+	if err := checkDirWritable(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// isGameDirFree проверяет, что папки нет или в ней только служебные файлы лаунчера
+func isGameDirFree(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		if info, statErr := os.Stat(dir); statErr == nil && !info.IsDir() {
+			return false, newError("err.dir.not_a_dir", dir)
+		}
+		return false, newError("err.dir.read", dir, err)
+	}
+	for _, entry := range entries {
+		if !isReservedEntry(entry.Name()) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// hasGameInstall проверяет, установлена ли в папке игра
+func hasGameInstall(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, GameVersionFileName))
+	return err == nil
+}
+
+// checkDirWritable проверяет, что в папку (или в ближайшую существующую родительскую папку) можно писать
+func checkDirWritable(dir string) error {
+	existing := dir
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			if !info.IsDir() {
+				return newError("err.dir.not_a_dir", existing)
+			}
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return newError("err.dir.unavailable", dir, err)
+		}
+		existing = parent
+	}
+
+	file, err := os.CreateTemp(existing, ".launcher-write-test-*")
+	if err != nil {
+		return newError("err.dir.not_writable_path", ErrDirNotWritable, existing)
+	}
+	file.Close()
+	os.Remove(file.Name())
+	return nil
+}
+
+// ResolveMoveTarget проверяет папку, в которую переносится установленная игра
+func ResolveMoveTarget(src, path string) (string, error) {
+	dst, err := ResolveInstallDir(path)
+	if err != nil {
+		return "", err
+	}
+	if dst == src {
+		return "", newError("err.move.same_dir")
+	}
+	if isSubPath(src, dst) || isSubPath(dst, src) {
+		return "", newError("err.move.nested")
+	}
+	if hasGameInstall(dst) {
+		return "", newError("err.move.target_has_game", dst)
+	}
+	return dst, nil
+}
+
+// isSubPath проверяет, что path находится внутри dir
+func isSubPath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// moveEntries возвращает файлы игры, которые нужно перенести: все, кроме лаунчера и служебных файлов
+func moveEntries(src, launcherPath string) ([]string, error) {
+	dirEntries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, newError("err.move.read_src", src, err)
+	}
+	var names []string
+	for _, entry := range dirEntries {
+		if isReservedEntry(entry.Name()) || filepath.Join(src, entry.Name()) == launcherPath {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+// moveGameInstall переносит установленную игру из src в dst. На одном диске файлы
+// переименовываются, между дисками копируются с проверкой и только потом удаляются.
+// При ошибке или отмене игра остается на старом месте
+func moveGameInstall(ctx context.Context, src, dst, launcherPath string, progressChan chan<- InstallProgress) error {
+	if HasIncompleteInstall(src) {
+		return newError("err.move.incomplete")
+	}
+	names, err := moveEntries(src, launcherPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return newError("err.move.create_dst", dst, err)
+	}
+
+	progressChan <- InstallProgress{Current: 0, Total: 100, Message: T("move.moving_files")}
+	renamed, err := renameEntries(src, dst, names)
+	if err != nil {
+		return err
+	}
+	if renamed {
+		progressChan <- InstallProgress{Current: 90, Total: 100, Message: T("move.checking_moved")}
+		if err := ValidateGameInstall(dst, nil); err != nil {
+			// Возвращаем игру на место, она была исправна до переноса
+			renameEntries(dst, src, names)
+			return err
+		}
+		progressChan <- InstallProgress{Current: 100, Total: 100, Message: T("move.done")}
+		return nil
+	}
+
+	LogLauncher("Перенос: %s и %s на разных дисках, копируем файлы", src, dst)
+	if err := copyEntries(ctx, src, dst, names, progressChan); err != nil {
+		removeEntries(dst, names)
+		return err
+	}
+
+	progressChan <- InstallProgress{Current: 90, Total: 100, Message: T("move.checking_copied")}
+	if err := verifyCopiedEntries(ctx, src, dst, names); err != nil {
+		removeEntries(dst, names)
+		return err
+	}
+	if err := ValidateGameInstall(dst, nil); err != nil {
+		removeEntries(dst, names)
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		removeEntries(dst, names)
+		return err
+	}
+
+	progressChan <- InstallProgress{Current: 95, Total: 100, Message: T("move.removing_old")}
+	if err := removeEntries(src, names); err != nil {
+		// Игра уже целиком на новом месте, остатки на старом не мешают работе
+		LogLauncher("Перенос: не удалось удалить старые файлы: %v", err)
+		ShowStyledMessage(Warn, T("move.remove_old_failed", err))
+	}
+	progressChan <- InstallProgress{Current: 100, Total: 100, Message: T("move.done")}
+	return nil
+}
+
+// renameEntries переименовывает файлы из одной папки в другую. Если папки на разных дисках
+// и первый файл переименовать нельзя, возвращает false без ошибки. Остальные ошибки
+// (нет прав, файл занят) возвращаются: копирование их не исправит
+func renameEntries(src, dst string, names []string) (bool, error) {
+	for i, name := range names {
+		err := os.Rename(filepath.Join(src, name), filepath.Join(dst, name))
+		if err == nil {
+			continue
+		}
+		if i == 0 && isCrossDeviceError(err) {
+			return false, nil
+		}
+		// Сбой посреди переноса: возвращаем уже перенесенные файлы
+		for _, moved := range names[:i] {
+			os.Rename(filepath.Join(dst, moved), filepath.Join(src, moved))
+		}
+		return false, newError("err.move.rename", name, err)
+	}
+	return true, nil
+}
+
+// copyEntries копирует файлы игры с сохранением прав и символических ссылок
+func copyEntries(ctx context.Context, src, dst string, names []string, progressChan chan<- InstallProgress) error {
+	var totalBytes int64
+	for _, name := range names {
+		filepath.WalkDir(filepath.Join(src, name), func(path string, d fs.DirEntry, err error) error {
+			if err == nil && d.Type().IsRegular() {
+				if info, err := d.Info(); err == nil {
+					totalBytes += info.Size()
+				}
+			}
+			return nil
+		})
+	}
+	if totalBytes == 0 {
+		totalBytes = 1
+	}
+
+	var copiedBytes int64
+	lastPercent := -1
+	for _, name := range names {
+		err := filepath.WalkDir(filepath.Join(src, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(dst, rel)
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case d.IsDir():
+				return os.MkdirAll(target, info.Mode().Perm())
+			case d.Type()&fs.ModeSymlink != 0:
+				link, err := os.Readlink(path)
+				if err != nil {
+					return err
+				}
+				return os.Symlink(link, target)
+			case d.Type().IsRegular():
+				if err := copyFile(ctx, path, target, info.Mode().Perm()); err != nil {
+					return newError("err.move.copy", rel, err)
+				}
+				copiedBytes += info.Size()
+				percent := int(copiedBytes * 90 / totalBytes)
+				if percent != lastPercent {
+					lastPercent = percent
+					progressChan <- InstallProgress{
+						Current: percent,
+						Total:   100,
+						Message: T("move.copied", float64(copiedBytes)/1024/1024, float64(totalBytes)/1024/1024),
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(ctx context.Context, src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, &contextReader{ctx: ctx, r: in}); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// verifyCopiedEntries сверяет скопированные файлы с исходными по типу, размеру и SHA-256
+// содержимого. Исходные файлы удаляются только после этой проверки
+func verifyCopiedEntries(ctx context.Context, src, dst string, names []string) error {
+	for _, name := range names {
+		err := filepath.WalkDir(filepath.Join(src, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			srcInfo, err := os.Lstat(path)
+			if err != nil {
+				return err
+			}
+			dstInfo, err := os.Lstat(filepath.Join(dst, rel))
+			if err != nil {
+				return newError("err.move.not_copied", ErrInvalidInstall, rel)
+			}
+			if srcInfo.Mode().Type() != dstInfo.Mode().Type() {
+				return newError("err.move.type_mismatch", ErrInvalidInstall, rel)
+			}
+			if !srcInfo.Mode().IsRegular() {
+				return nil
+			}
+			if srcInfo.Size() != dstInfo.Size() {
+				return newError("err.move.size_mismatch", ErrInvalidInstall, rel)
+			}
+			srcHash, err := calcFileSHA256(ctx, path)
+			if err != nil {
+				return err
+			}
+			dstHash, err := calcFileSHA256(ctx, filepath.Join(dst, rel))
+			if err != nil {
+				return err
+			}
+			if srcHash != dstHash {
+				return newError("err.move.content_mismatch", ErrInvalidInstall, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeEntries удаляет перечисленные файлы и папки
+func removeEntries(dir string, names []string) error {
+	var firstErr error
+	for _, name := range names {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,525 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InstallLocationModel - модель TUI для выбора папки установки игры
+type InstallLocationModel struct {
+	width    int
+	height   int
+	input    []rune
+	resolved string // Итоговая папка установки после проверки
+	errorMsg string
+	chosen   bool
+}
+
+// NewInstallLocationModel создает экран выбора папки установки с предложенной папкой
+func NewInstallLocationModel(defaultDir string) InstallLocationModel {
+	return InstallLocationModel{
+		width:  80,
+		height: 24,
+		input:  []rune(defaultDir),
+	}
+}
+
+func (m InstallLocationModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m InstallLocationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			dir, err := ResolveInstallDir(string(m.input))
+			if err != nil {
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			m.resolved = dir
+			m.chosen = true
+			return m, tea.Quit
+		default:
+			m.input = editRunes(m.input, msg)
+			m.errorMsg = ""
+		}
+	}
+	return m, nil
+}
+
+func (m InstallLocationModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("location.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+	content += titleStyle.Width(m.width).Render(T("location.title")) + "\n\n"
+
+	inputBox := boxStyle.Width(m.width - 10).Align(lipgloss.Left).Render(string(m.input) + "▏")
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(inputBox) + "\n\n"
+
+	hint := T("location.hint", GameFolderName)
+	content += statusStyle.Width(m.width).Render(hint) + "\n"
+
+	if m.errorMsg != "" {
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(errorStyle.Render("❌ "+m.errorMsg)) + "\n"
+	}
+
+	footer := footerStyle.Width(m.width).Render(T("location.footer"))
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+// RunInstallLocationTUI предлагает выбрать папку установки игры.
+// Возвращает выбранную папку и false, если пользователь отказался от установки
+func RunInstallLocationTUI(defaultDir string) (string, bool, error) {
+	p := tea.NewProgram(NewInstallLocationModel(defaultDir), tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", false, err
+	}
+	locationModel := finalModel.(InstallLocationModel)
+	return locationModel.resolved, locationModel.chosen, nil
+}
+
+// MoveModel - модель TUI для переноса установленной игры в другую папку
+type MoveModel struct {
+	width         int
+	height        int
+	state         InstallState
+	progress      InstallProgress
+	errorMsg      string
+	src           string
+	dst           string
+	spinner       int
+	tickCount     int
+	cancel        context.CancelFunc // Отменяет фоновый перенос
+	confirmCancel bool               // Показывается запрос подтверждения отмены
+}
+
+// NewMoveModel создает модель переноса игры
+func NewMoveModel(src, dst string) MoveModel {
+	return MoveModel{
+		width:    80,
+		height:   24,
+		state:    StateExtracting,
+		src:      src,
+		dst:      dst,
+		progress: InstallProgress{Current: 0, Total: 100, Message: T("move.preparing")},
+	}
+}
+
+func (m MoveModel) Init() tea.Cmd {
+	return m.tickCmd()
+}
+
+func (m MoveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case TickMsg:
+		m.tickCount++
+		m.spinner = (m.spinner + 1) % len(spinnerFrames)
+		if isOperationActive(m.state) || m.state == StateCancelling {
+			return m, m.tickCmd()
+		}
+		return m, nil
+
+	case InstallProgressMsg:
+		m.progress = InstallProgress(msg)
+		return m, nil
+
+	case InstallErrorMsg:
+		m.state = StateError
+		m.errorMsg = string(msg)
+		m.confirmCancel = false
+		return m, nil
+
+	case InstallCompleteMsg:
+		m.state = StateCompleted
+		m.confirmCancel = false
+		return m, nil
+
+	case InstallCancelledMsg:
+		m.state = StateCancelled
+		m.confirmCancel = false
+		return m, nil
+
+	case tea.KeyMsg:
+		if isOperationActive(m.state) {
+			key := msg.String()
+			if m.confirmCancel {
+				if isConfirmKey(key) {
+					m.confirmCancel = false
+					m.state = StateCancelling
+					if m.cancel != nil {
+						m.cancel()
+					}
+				} else if isRejectKey(key) {
+					m.confirmCancel = false
+				}
+				return m, nil
+			}
+			switch key {
+			case "ctrl+c", "q", "esc":
+				m.confirmCancel = true
+			}
+			return m, nil
+		}
+		if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
+			switch msg.String() {
+			case "enter", " ", "ctrl+c", "q", "esc":
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m MoveModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("move.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+	content += statusStyle.Width(m.width).Render(fmt.Sprintf("%s → %s", m.src, m.dst)) + "\n"
+
+	switch m.state {
+	case StateExtracting:
+		statusMsg := T("move.in_progress", spinnerFrames[m.spinner])
+		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
+
+	case StateCompleted:
+		content += installCompleteStyle.Width(m.width).Render(T("move.completed")) + "\n\n"
+
+	case StateError:
+		content += installErrorStyle.Width(m.width).Render(T("move.error")) + "\n"
+		content += installErrorStyle.Width(m.width).Render(m.errorMsg) + "\n\n"
+		content += installStatusStyle.Width(m.width).Render(T("move.stayed")) + "\n\n"
+
+	case StateCancelling:
+		statusMsg := T("move.cancelling", spinnerFrames[m.spinner])
+		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
+
+	case StateCancelled:
+		content += installErrorStyle.Width(m.width).Render(T("move.cancelled")) + "\n"
+		content += installStatusStyle.Width(m.width).Render(T("move.stayed")) + "\n\n"
+	}
+
+	if m.state != StateError && m.state != StateCancelled {
+		progressBar := m.renderProgressBar()
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(progressBar) + "\n\n"
+		content += installStatusStyle.Width(m.width).Render(m.progress.Message) + "\n\n"
+	}
+
+	if m.confirmCancel {
+		content += renderCancelConfirm(m.width, T("move.confirm_cancel")) + "\n\n"
+	}
+
+	var footer string
+	if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
+		footer = footerStyle.Width(m.width).Render(T("ui.press_enter_to_continue"))
+	} else if isOperationActive(m.state) && !m.confirmCancel {
+		footer = footerStyle.Width(m.width).Render(T("ui.footer_cancel"))
+	}
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+func (m MoveModel) renderProgressBar() string {
+	barWidth := 50
+	percent := float64(m.progress.Current) / float64(m.progress.Total)
+	if percent > 1.0 {
+		percent = 1.0
+	}
+
+	filled := int(float64(barWidth) * percent)
+	progressBar := installProgressStyle.Render(strings.Repeat("█", filled)) +
+		installProgressBgStyle.Render(strings.Repeat("░", barWidth-filled))
+
+	percentText := fmt.Sprintf(" %d%%", int(percent*100))
+	return fmt.Sprintf("[%s]%s", progressBar, percentText)
+}
+
+func (m MoveModel) tickCmd() tea.Cmd {
+	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+// MoveGame переносит установленную игру в новую папку без интерфейса: захватывает блокировку
+// новой папки, переносит файлы, запоминает папку в настройках и освобождает старую блокировку.
+// Возвращает блокировку, действующую после переноса
+func MoveGame(ctx context.Context, lock *InstanceLock, src, dst, launcherPath string, progressChan chan<- InstallProgress) (*InstanceLock, error) {
+	if lock.IsGameRunning() {
+		return lock, newError("err.move.game_running")
+	}
+	dstLock, holder, err := AcquireInstanceLock(dst)
+	if errors.Is(err, ErrInstanceLocked) {
+		return lock, errors.New(describeLockHolder(holder))
+	}
+	if err != nil {
+		return lock, err
+	}
+
+	LogLauncher("Перенос игры: %s → %s", src, dst)
+	if err := moveGameInstall(ctx, src, dst, launcherPath, progressChan); err != nil {
+		LogLauncher("Перенос игры не выполнен: %v", err)
+		dstLock.Release()
+		os.Remove(dst)
+		return lock, err
+	}
+	if err := RememberGameDir(launcherPath, dst); err != nil {
+		ShowStyledMessage(Warn, T("move.remember_failed", err))
+	}
+	lock.Release()
+	// Старая папка удаляется, только если в ней ничего не осталось
+	os.Remove(src)
+	LogLauncher("Перенос игры завершен")
+	return dstLock, nil
+}
+
+// RunMoveGameTUI переносит установленную игру в новую папку с отображением прогресса
+func RunMoveGameTUI(lock *InstanceLock, src, dst, launcherPath string) (*InstanceLock, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	model := NewMoveModel(src, dst)
+	model.cancel = cancel
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	progressChan := make(chan InstallProgress, 100)
+	resultLock := lock
+	var moveErr error
+	go func() {
+		resultLock, moveErr = MoveGame(ctx, lock, src, dst, launcherPath, progressChan)
+		close(progressChan)
+	}()
+
+	go func() {
+		for progress := range progressChan {
+			p.Send(InstallProgressMsg(progress))
+		}
+		switch {
+		case moveErr == nil:
+			p.Send(InstallCompleteMsg{})
+		case errors.Is(moveErr, context.Canceled):
+			p.Send(InstallCancelledMsg{})
+		default:
+			p.Send(InstallErrorMsg(moveErr.Error()))
+		}
+	}()
+
+	if _, err := p.Run(); err != nil {
+		cancel()
+	}
+	// Дожидаемся завершения переноса, даже если интерфейс закрылся раньше
+	for range progressChan {
+	}
+	return resultLock, moveErr
+}
+
+// Варианты на экране папки игры, недоступной для записи
+const (
+	RelocateToUserDir = iota
+	RelocateChooseDir
+	RelocateQuit
+)
+
+// RelocateModel - модель TUI, предлагающая перенести игру из папки, недоступной для записи
+type RelocateModel struct {
+	width    int
+	height   int
+	cursor   int
+	selected bool
+	problem  string
+	errorMsg string // Почему не удалось использовать выбранную папку
+	choices  []string
+}
+
+// NewRelocateModel создает экран выбора новой папки для игры
+func NewRelocateModel(problem, userDir string) RelocateModel {
+	return RelocateModel{
+		width:   80,
+		height:  24,
+		problem: problem,
+		choices: []string{T("relocate.use_dir", userDir), T("relocate.choose_dir"), T("menu.exit")},
+	}
+}
+
+func (m RelocateModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RelocateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.choices)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			m.selected = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m RelocateModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("menu.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(errorStyle.Render("❌ "+m.problem)) + "\n\n"
+	content += statusStyle.Width(m.width).Render(T("relocate.hint")) + "\n\n"
+	if m.errorMsg != "" {
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(errorStyle.Render("❌ "+m.errorMsg)) + "\n\n"
+	}
+
+	menu := ""
+	for i, choice := range m.choices {
+		if m.cursor == i {
+			menu += selectedItemStyle.Render("▶ "+choice) + "\n"
+		} else {
+			menu += menuItemStyle.Render("  "+choice) + "\n"
+		}
+	}
+	menuBox := boxStyle.Align(lipgloss.Left).Render(strings.TrimSuffix(menu, "\n"))
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(menuBox)
+
+	footer := footerStyle.Width(m.width).Render(T("menu.footer"))
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+// RunRelocateTUI объясняет, что папка игры недоступна для записи, и предлагает новую папку.
+// Установленная игра копируется на новое место, пустая папка просто запоминается в настройках.
+// Возвращает новую папку игры и false, если пользователь решил выйти
+func RunRelocateTUI(gameDirPath, launcherPath string, problem error) (string, bool, error) {
+	userDir := userDataGameDirPath()
+	errorMsg := ""
+	for {
+		model := NewRelocateModel(problem.Error(), userDir)
+		model.errorMsg = errorMsg
+		p := tea.NewProgram(model, tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			return "", false, err
+		}
+		relocateModel := finalModel.(RelocateModel)
+		if !relocateModel.selected || relocateModel.cursor == RelocateQuit {
+			return "", false, nil
+		}
+
+		target := userDir
+		if relocateModel.cursor == RelocateChooseDir {
+			dir, ok, err := RunInstallLocationTUI(userDir)
+			if err != nil {
+				return "", false, err
+			}
+			if !ok {
+				continue
+			}
+			target = dir
+		}
+
+		if !hasGameInstall(gameDirPath) {
+			dir, err := ResolveInstallDir(target)
+			if err != nil {
+				errorMsg = T("settings.bad_game_dir", err)
+				continue
+			}
+			if err := RememberGameDir(launcherPath, dir); err != nil {
+				ShowStyledMessage(Warn, T("launcher.remember_dir_failed", err))
+			}
+			return dir, true, nil
+		}
+
+		// Из папки только для чтения файлы не удалить, поэтому игра копируется, а старые
+		// файлы остаются на месте. Блокировку старой папки захватить нельзя, перенос идет без нее
+		dst, err := ResolveMoveTarget(gameDirPath, target)
+		if err != nil {
+			errorMsg = T("settings.move_rejected", err)
+			continue
+		}
+		lock, err := RunMoveGameTUI(nil, gameDirPath, dst, launcherPath)
+		if err != nil {
+			errorMsg = T("relocate.not_moved", err)
+			continue
+		}
+		// Основной цикл захватит блокировку новой папки сам
+		lock.Release()
+		return dst, true, nil
+	}
+}
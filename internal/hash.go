@@ -1,38 +1,42 @@
 package internal
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"io"
 	"net/http"
 	"os"
 )
 
-func checkHash(archivePath string) error {
-	remoteHash, err := downloadRemoteHash()
+func checkHash(ctx context.Context, archivePath string) error {
+	remoteHash, err := downloadRemoteHash(ctx)
 	if err != nil {
 		return err
 	}
-	localHash, err := calcFileSHA256(archivePath)
+	localHash, err := calcFileSHA256(ctx, archivePath)
 	if err != nil {
 		return err
 	}
 	if remoteHash != localHash {
-		return fmt.Errorf("хеш архива не совпадает")
+		return newError("err.hash_mismatch")
 	}
 	return nil
 }
 
-func downloadRemoteHash() (string, error) {
+func downloadRemoteHash(ctx context.Context) (string, error) {
 	hashUrl := GetHashURL()
-	resp, err := http.Get(hashUrl)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hashUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("сервер вернул статус %d", resp.StatusCode)
+		return "", newError("err.server_status", resp.StatusCode)
 	}
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -48,14 +52,14 @@ func trimHash(data []byte) []byte {
 	return data
 }
 
-func calcFileSHA256(path string) (string, error) {
+func calcFileSHA256(ctx context.Context, path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
 	defer f.Close()
 	sha := sha256.New()
-	if _, err := io.Copy(sha, f); err != nil {
+	if _, err := io.Copy(sha, &contextReader{ctx: ctx, r: f}); err != nil {
 		return "", err
 	}
 	return hex.EncodeToString(sha.Sum(nil)), nil
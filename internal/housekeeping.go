@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const (
+	// leftoverGracePeriod - более свежие файлы могут использоваться другим процессом, их не трогаем
+	leftoverGracePeriod = time.Minute
+	// resumableMaxAge - прерванные загрузки старше этого срока не продолжаем
+	resumableMaxAge = 7 * 24 * time.Hour
+)
+
+// classifyArchive решает, можно ли продолжить загрузку оставшегося архива.
+// Возвращает признак возобновляемости и причину решения для журнала
+func classifyArchive(path string, info os.FileInfo) (bool, string) {
+	if info.Size() == 0 {
+		return false, T("cleanup.empty_file")
+	}
+	if time.Since(info.ModTime()) > resumableMaxAge {
+		return false, T("cleanup.too_old")
+	}
+	meta, err := readArchiveMeta(path)
+	if err != nil {
+		return false, T("cleanup.no_meta")
+	}
+	if meta.URL != GetArchiveURL() {
+		return false, T("cleanup.other_archive")
+	}
+	if meta.ETag == "" && meta.LastModified == "" {
+		return false, T("cleanup.no_resume")
+	}
+	if meta.Size <= 1 || info.Size() >= meta.Size {
+		return false, T("cleanup.complete")
+	}
+	return true, T("cleanup.resumable")
+}
+
+// findResumableArchive ищет в папке загрузок самую свежую прерванную загрузку архива игры
+func findResumableArchive() string {
+	matches, err := filepath.Glob(filepath.Join(GetDownloadDir(), ArchiveNameTemplate))
+	if err != nil {
+		return ""
+	}
+
+	var bestPath string
+	var bestTime time.Time
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < leftoverGracePeriod {
+			continue
+		}
+		if resumable, _ := classifyArchive(path, info); resumable && info.ModTime().After(bestTime) {
+			bestPath = path
+			bestTime = info.ModTime()
+		}
+	}
+	return bestPath
+}
+
+// CleanupLeftovers удаляет остатки прерванных операций: временные архивы,
+// промежуточные файлы самообновления и скрипты обновления.
+// Прерванные загрузки, которые можно продолжить, и архив из журнала установки сохраняются.
+// Все действия пишутся в журнал лаунчера, удаленные файлы возвращаются списком
+func CleanupLeftovers(launcherPath, gameDirPath string) []string {
+	var removed []string
+	for _, dir := range archiveSearchDirs() {
+		cleanupTempArchives(dir, protectedArchivePath(gameDirPath), &removed)
+	}
+	cleanupLauncherUpdateFiles(filepath.Dir(launcherPath), &removed)
+	return removed
+}
+
+func cleanupTempArchives(tempDir, protectedPath string, removed *[]string) {
+	matches, err := filepath.Glob(filepath.Join(tempDir, ArchiveNameTemplate))
+	if err != nil {
+		LogLauncher("Уборка: ошибка при поиске временных архивов: %v", err)
+		return
+	}
+
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if path == protectedPath {
+			LogLauncher("Уборка: %s сохранен (нужен для продолжения распаковки)", path)
+			continue
+		}
+		if time.Since(info.ModTime()) < leftoverGracePeriod {
+			LogLauncher("Уборка: %s пропущен, возможно используется другим процессом", path)
+			continue
+		}
+
+		resumable, reason := classifyArchive(path, info)
+		if resumable {
+			LogLauncher("Уборка: %s сохранен (%s, %d байт)", path, reason, info.Size())
+			continue
+		}
+		removeLeftover(path, reason, removed)
+		removeLeftover(path+archiveMetaSuffix, T("cleanup.orphan_meta_removed"), removed)
+	}
+
+	// Описания загрузок, архив которых уже удален
+	metas, err := filepath.Glob(filepath.Join(tempDir, ArchiveNameTemplate+archiveMetaSuffix))
+	if err != nil {
+		return
+	}
+	for _, metaPath := range metas {
+		archivePath := metaPath[:len(metaPath)-len(archiveMetaSuffix)]
+		if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+			removeLeftover(metaPath, T("cleanup.orphan_meta"), removed)
+		}
+	}
+}
+
+func cleanupLauncherUpdateFiles(dir string, removed *[]string) {
+	ext := ""
+	scriptName := "update_launcher.sh"
+	if runtime.GOOS == "windows" {
+		ext = ".exe"
+		scriptName = "update_launcher.bat"
+	}
+
+	leftovers := map[string]string{
+		"SubmarineLauncher_new" + ext: T("cleanup.new_launcher"),
+		"SubmarineLauncher_old" + ext: T("cleanup.old_launcher"),
+		scriptName:                    T("cleanup.update_script"),
+	}
+	for name, reason := range leftovers {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		// Скрипт обновления мог только что запустить этот лаунчер и еще не удалить себя
+		if time.Since(info.ModTime()) < leftoverGracePeriod {
+			LogLauncher("Уборка: %s пропущен, самообновление может быть еще в процессе", path)
+			continue
+		}
+		removeLeftover(path, reason, removed)
+	}
+}
+
+func removeLeftover(path, reason string, removed *[]string) {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return
+	}
+	if err := os.RemoveAll(path); err != nil {
+		LogLauncher("Уборка: не удалось удалить %s (%s): %v", path, reason, err)
+		return
+	}
+	LogLauncher("Уборка: удален %s (%s)", path, reason)
+	*removed = append(*removed, fmt.Sprintf("%s (%s)", path, reason))
+}
@@ -0,0 +1,209 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Языки интерфейса
+const (
+	LanguageAuto = "auto" // Язык из переменных окружения LC_ALL, LC_MESSAGES и LANG
+	LanguageRu   = "ru"
+	LanguageEn   = "en"
+
+	// defaultLanguage - язык, на который переводятся сообщения, отсутствующие в других каталогах
+	defaultLanguage = LanguageRu
+)
+
+// catalogs - каталоги сообщений по языкам: идентификатор сообщения → шаблон для fmt.Sprintf
+var catalogs = map[string]map[string]string{
+	LanguageRu: messagesRu,
+	LanguageEn: messagesEn,
+}
+
+// До загрузки настроек язык определяется по окружению
+var currentLanguage = detectSystemLanguage()
+
+// SetLanguage переключает язык интерфейса. Для auto язык определяется по окружению
+func SetLanguage(language string) {
+	if language == LanguageAuto || language == "" {
+		language = detectSystemLanguage()
+	}
+	if _, ok := catalogs[language]; !ok {
+		language = defaultLanguage
+	}
+	currentLanguage = language
+}
+
+// CurrentLanguage возвращает действующий язык интерфейса
+func CurrentLanguage() string {
+	return currentLanguage
+}
+
+// detectSystemLanguage определяет язык по локали из LC_ALL, LC_MESSAGES и LANG.
+// Русская локаль - русский язык, любая другая, включая C и POSIX, - английский.
+// Локаль C стоит по умолчанию в контейнерах и CI, и русский там никто не выбирал
+func detectSystemLanguage() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(env)
+		if locale == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(locale), LanguageRu) {
+			return LanguageRu
+		}
+		return LanguageEn
+	}
+	return defaultLanguage
+}
+
+// T возвращает сообщение на текущем языке, подставляя аргументы по шаблону каталога.
+// Если перевода нет, используется русский каталог, а если нет и его - сам идентификатор
+func T(id string, args ...any) string {
+	format, ok := catalogs[currentLanguage][id]
+	if !ok {
+		format, ok = catalogs[defaultLanguage][id]
+	}
+	if !ok {
+		format = id
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// LocalizedError - ошибка с идентификатором сообщения из каталога. Текст собирается
+// при выводе, поэтому ошибка показывается на языке, действующем в этот момент
+type LocalizedError struct {
+	ID   string
+	Args []any
+}
+
+// newError создает ошибку с сообщением из каталога. Ошибки среди аргументов
+// считаются причинами и доступны через errors.Is и errors.As
+func newError(id string, args ...any) error {
+	return &LocalizedError{ID: id, Args: args}
+}
+
+func (e *LocalizedError) Error() string {
+	return T(e.ID, e.Args...)
+}
+
+func (e *LocalizedError) Unwrap() []error {
+	var causes []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			causes = append(causes, err)
+		}
+	}
+	return causes
+}
+
+// messageID возвращает идентификатор сообщения ошибки или пустую строку, если ошибка
+// создана не из каталога
+func messageID(err error) string {
+	var localized *LocalizedError
+	if errors.As(err, &localized) {
+		return localized.ID
+	}
+	return ""
+}
+
+// LocalizedText - текст с вариантами на разных языках, например из манифеста.
+// В YAML записывается строкой (как в ранних версиях манифеста) или словарем {ru: ..., en: ...}
+type LocalizedText map[string]string
+
+// neutralLanguage - ключ текста, записанного строкой без указания языка
+const neutralLanguage = ""
+
+// normalizeLanguage приводит код языка к виду каталога: "en-US" и "EN_us" - "en"
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_."); i != -1 {
+		language = language[:i]
+	}
+	return language
+}
+
+// UnmarshalYAML разбирает текст из строки или словаря языков
+func (t *LocalizedText) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Tag == "!!null" {
+			*t = nil
+			return nil
+		}
+		var text string
+		if err := value.Decode(&text); err != nil {
+			return err
+		}
+		*t = LocalizedText{neutralLanguage: text}
+		return nil
+	case yaml.MappingNode:
+		var variants map[string]string
+		if err := value.Decode(&variants); err != nil {
+			return err
+		}
+		text := make(LocalizedText, len(variants))
+		for language, variant := range variants {
+			text[normalizeLanguage(language)] = variant
+		}
+		*t = text
+		return nil
+	}
+	return newError("err.localized_text.format", value.Line)
+}
+
+// MarshalJSON сохраняет текст без языка строкой, как он был записан в манифесте
+func (t LocalizedText) MarshalJSON() ([]byte, error) {
+	if text, ok := t[neutralLanguage]; ok && len(t) == 1 {
+		return json.Marshal(text)
+	}
+	return json.Marshal(map[string]string(t))
+}
+
+// String возвращает вариант на текущем языке. Если его нет, берется текст без языка,
+// затем английский, русский и любой другой по алфавиту кода языка
+func (t LocalizedText) String() string {
+	for _, language := range []string{currentLanguage, neutralLanguage, LanguageEn, defaultLanguage} {
+		if text := t[language]; text != "" {
+			return text
+		}
+	}
+	languages := make([]string, 0, len(t))
+	for language := range t {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		if text := t[language]; text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// isYesAnswer проверяет ответ "да" в английской и русской раскладке
+func isYesAnswer(answer string) bool {
+	switch answer {
+	case "y", "Y", "н", "Н":
+		return true
+	}
+	return false
+}
+
+// isNoAnswer проверяет ответ "нет" в английской и русской раскладке
+func isNoAnswer(answer string) bool {
+	switch answer {
+	case "n", "N", "т", "Т":
+		return true
+	}
+	return false
+}
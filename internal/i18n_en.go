@@ -0,0 +1,474 @@
+package internal
+
+// messagesEn - английский каталог сообщений
+var messagesEn = map[string]string{
+	// Главное меню и общие элементы интерфейса
+	"menu.run":                   "🎮 Play",
+	"menu.install":               "📦 Install game",
+	"menu.update":                "🔄 Update game",
+	"menu.settings":              "⚙️  Settings",
+	"menu.exit":                  "🚪 Exit",
+	"menu.retry":                 "🔄 Check again",
+	"menu.logo":                  "🚢 SUBMARINE LAUNCHER 🚢",
+	"menu.read_only":             "👁️  View only. %s",
+	"menu.game_not_installed":    "🔴 Game is not installed",
+	"menu.update_available":      "🟡 Update available",
+	"menu.game_ready":            "🟢 Game is ready to play",
+	"menu.title":                 "CHOOSE AN ACTION",
+	"menu.footer_news":           "↑/↓ - navigate • Tab - news • Enter - select • Esc/Q - exit",
+	"menu.maintenance_over":      "Maintenance is over, the game is available again",
+	"menu.game_update_found":     "Game version %s is available",
+	"menu.launcher_update_found": "Launcher version %s is available, restart the launcher to update",
+	"menu.footer":                "↑/↓ - navigate • Enter - select • Esc/Q - exit",
+	"ui.confirm_no_terminal":     "%s (no terminal, default answer: no)",
+	"ui.confirm_hint":            "y to confirm, any other key to cancel",
+	"ui.press_enter_to_exit":     "Press Enter to exit...",
+	"ui.press_enter_to_continue": "Press Enter to continue",
+	"ui.interface_error":         "Interface error: %v",
+	"ui.goodbye":                 "Launcher closed! 👋",
+	"err.console_handle":         "failed to get console handle",
+	"err.console_mode_get":       "failed to get console mode",
+	"err.console_mode_set":       "failed to set console mode",
+
+	// Запуск лаунчера
+	"launcher.executable_path_error":         "Failed to get the executable path: %v",
+	"launcher.game_dir_overridden_read_only": "%v. Set another folder with the SUBMARINE_GAME_DIR variable or the --game-dir flag",
+	"launcher.log_open_failed":               "Failed to open the launcher log: %v",
+	"launcher.update_check_failed":           "Failed to check for launcher updates: %v",
+	"launcher.update_manual":                 "Launcher version %s is available. %s",
+	"launcher.update_found":                  "Launcher update found: %s → %s",
+	"launcher.update_failed":                 "Launcher update failed: %v",
+	"launcher.lock_failed":                   "Failed to lock the game folder: %v",
+	"launcher.recover_failed":                "Failed to recover the interrupted installation: %v",
+	"launcher.lock_check_failed":             "Failed to check the lock: %v",
+	"launcher.switch_dir_failed":             "Failed to switch to the new game folder: %v",
+	"launcher.manifest_failed":               "Failed to fetch the manifest: %v",
+	"launcher.game_running":                  "The game is already running, wait for it to exit",
+	"launcher.maintenance_blocked":           "The game is unavailable due to maintenance",
+	"launcher.choose_dir_failed":             "Failed to choose the install folder: %v",
+	"launcher.remember_dir_failed":           "Failed to save the game folder in settings: %v",
+	"launcher.run_failed":                    "Failed to start the game: %v",
+
+	// Настройки
+	"settings.game_dir.title":         "Game folder",
+	"settings.game_dir.hint":          "empty - folder next to the launcher",
+	"settings.channel.title":          "Update channel",
+	"settings.channel.hint":           "stable, beta or another server channel",
+	"settings.language.title":         "Language",
+	"settings.language.hint":          "interface language, auto - from LANG and LC_ALL",
+	"settings.bandwidth.title":        "Download speed limit, KB/s",
+	"settings.bandwidth.hint":         "0 - unlimited",
+	"settings.log_retention.title":    "Keep game logs, days",
+	"settings.log_retention.hint":     "0 - keep all logs",
+	"settings.manifest_refresh.title": "Check for updates in the menu, minutes",
+	"settings.manifest_refresh.hint":  "0 - only when the launcher starts",
+	"settings.update_reminder.title":  "Remind about a postponed update, days",
+	"settings.update_reminder.hint":   "0 - at the next launcher start",
+	"settings.launch_args.title":      "Game launch arguments",
+	"settings.launch_args.hint":       "space separated, passed to the game after -launcher",
+	"settings.theme.title":            "Theme",
+	"settings.theme.hint":             "interface colors",
+	"settings.flag_usage":             "%s: %s",
+	"err.settings.invalid_path":       "invalid path: %v",
+	"err.settings.not_a_dir":          "%s is not a folder",
+	"err.settings.invalid_channel":    "invalid channel name: %s",
+	"err.settings.unknown_language":   "unknown language: %s (allowed: auto, ru, en)",
+	"err.settings.unknown_theme":      "unknown theme: %s (allowed: dark, light)",
+	"err.settings.not_non_negative":   "expected a whole number not less than 0: %s",
+	"err.settings.config_dir":         "failed to determine the config folder: %v",
+	"err.settings.read":               "failed to read settings: %v",
+	"err.settings.parse":              "failed to parse settings: %v",
+	"err.settings.field":              "%s: %v",
+	"err.settings.create_dir":         "failed to create the settings folder: %v",
+	"err.settings.write":              "failed to write settings: %v",
+	"err.settings.extra_args":         "unexpected arguments: %s",
+	"settings.source_flag":            "flag --%s",
+	"settings.source_env":             "variable %s",
+	"settings.defaults_used":          "Using default settings: %v",
+	"settings.move_rejected":          "Cannot move the game: %v",
+	"settings.bad_game_dir":           "Unsuitable game folder: %v",
+	"settings.save_failed":            "Failed to save settings: %v",
+	"settings.saved":                  "Settings saved",
+	"settings.title":                  "SETTINGS",
+	"settings.overridden":             "  (currently set by: %s)",
+	"settings.save":                   "💾 Save",
+	"settings.back":                   "↩️  Back",
+	"settings.footer_editing":         "Enter - apply • Esc - cancel • Ctrl+U - clear",
+	"settings.footer":                 "↑/↓ - navigate • ←/→ - choose • Enter - edit • Esc/Q - back",
+
+	// Командная строка и текстовый режим
+	"cli.not_installed":              "The game is not installed",
+	"cli.create_dir_failed":          "Failed to create the game folder: %v",
+	"cli.verify_ok":                  "Game files are intact: %s",
+	"cli.version_mismatch":           "Installed version is %s, --expect-version requires %s",
+	"cli.install_incomplete":         "The game installation is not complete",
+	"cli.move_target_required":       "Specify the new game folder: --to <path>",
+	"cli.removed":                    "Removed: %s",
+	"cli.move_cancelled":             "Move cancelled, the game stayed in place",
+	"cli.moving":                     "Moving the game: %s → %s",
+	"cli.cancelled":                  "Operation cancelled, installed files are unchanged",
+	"plain.updating":                 "Updating the game: %s → %s",
+	"cli.self_updating":              "Updating the launcher: %s → %s",
+	"cli.nothing_to_clean":           "Nothing to remove",
+	"cli.server_check_failed":        "Failed to check the server status: %v",
+	"cli.update_check_failed":        "Failed to check for updates: %v",
+	"cli.launcher_up_to_date":        "The launcher is up to date: %s",
+	"cli.already_installed":          "The game is already installed: %s",
+	"cli.already_running":            "The game is already running",
+	"cli.moved":                      "The game was moved to %s",
+	"plain.installing":               "The game is not installed, installing version %s",
+	"plain.no_server":                "The game is not installed and the update server is unavailable",
+	"cli.up_to_date":                 "The game is up to date: %s",
+	"cli.update_available":           "Update available: %s → %s",
+	"plain.launcher_update":          "Launcher update available: %s → %s. Run the self-update command to install it",
+	"plain.launcher_update_manual":   "Launcher update available: %s → %s. %s",
+	"cli.launch_outdated":            "Update %s is available, starting the installed version %s",
+	"cli.server_version_mismatch":    "The server has version %s, --expect-version requires %s",
+	"plain.game_dir_read_only":       "%v. Move the game with the move --to <path> command or set another folder with the --game-dir flag",
+	"plain.fallback_dir_failed":      "%v. Failed to choose another folder: %v",
+	"plain.fallback_dir":             "%v. The game will be installed to %s",
+	"cli.move_read_only":             "%v. The game will be copied, the old files stay in place",
+	"cli.error":                      "%v",
+	"cli.cmd.install":                "install the game",
+	"cli.cmd.update":                 "update the installed game",
+	"cli.cmd.verify":                 "verify the installed game files",
+	"cli.cmd.launch":                 "start the game and wait for it to exit",
+	"cli.cmd.status":                 "show the game and server status",
+	"cli.cmd.self_update":            "update the launcher without restarting",
+	"cli.cmd.move":                   "move the installed game to another folder",
+	"cli.cmd.clean":                  "remove leftovers of interrupted operations",
+	"cli.unknown_command":            "Unknown command: %s",
+	"cli.flag.expect_version":        "fail with code 9 if the game version differs (the launcher always installs the server version)",
+	"cli.flag.json":                  "print the result as JSON",
+	"cli.flag.force":                 "reinstall even if the game is up to date",
+	"cli.flag.to":                    "new game folder",
+	"cli.extra_args":                 "Unexpected arguments: %s",
+	"cli.usage":                      "Usage: SubmarineLauncher [command] [flags]\nWithout a command the interactive launcher starts, settings flags work there too.\n\nCommands:",
+	"cli.usage_flags":                "Flags:\n  --version <version> expected game version\n  --force             reinstall the game (install, update)\n  --to <path>         new game folder (move)\n  --json              JSON for status, NDJSON events for other commands\n  --plain             line-based output without colors and questions (also NO_COLOR or output not to a terminal)\n\nSettings (override the settings file, environment variables in brackets):",
+	"cli.done":                       "Done",
+	"cli.status.game_dir":            "Game folder:       %s",
+	"cli.status.installed":           "Installed:         %s",
+	"cli.status.not_installed":       "Installed:         no",
+	"cli.status.launcher":            "Launcher:          %s",
+	"cli.status.remote_game":         "Available game:    %s",
+	"cli.status.remote_launcher":     "Available launcher: %s",
+	"cli.status.update_required":     "Game update:       required",
+	"cli.status.update_not_required": "Game update:       not required",
+	"cli.status.maintenance":         "Maintenance:       %s",
+	"cli.status.no_maintenance":      "Maintenance:       none",
+	"cli.status.server_message":      "Server message:    %s",
+	"plain.level.error":              "[ERROR]",
+	"plain.level.warn":               "[WARNING]",
+	"plain.level.success":            "[DONE]",
+	"plain.level.info":               "[INFO]",
+
+	// Запуск игры
+	"game.starting":                 "Starting the game...",
+	"game.executable_not_found":     "Game executable not found: %s",
+	"err.game.executable_not_found": "game executable not found: %s",
+	"game.codesign_failed":          "Failed to remove the code signature: %v",
+	"game.codesign_removed":         "Code signature removed",
+	"game.chmod_failed":             "Failed to set execute permissions: %v",
+	"game.tts_chmod_failed":         "Failed to set execute permissions for TTS: %v",
+	"game.tts_check_failed":         "Failed to check TTS: %v",
+	"game.log_dir_fallback":         "Log folder %s is not writable, logs are saved to %s",
+	"game.log_create_failed":        "Failed to create the log file: %v",
+	"game.log_started":              "=== Game log started: %s ===",
+	"game.log_path":                 "Game path: %s",
+	"game.log_location":             "Game log is written to: %s",
+	"game.started":                  "Game started: %s",
+	"game.log_finished":             "=== Game log finished: %s ===",
+	"game.exited_with_error":        "The game exited with an error: %v",
+	"game.exited":                   "The game has exited",
+	"err.game.version_check":        "failed to check the game version: %v",
+	"err.game.version_read":         "failed to read the version file: %v",
+
+	// Папка игры и перенос
+	"err.dir.not_specified":       "no folder specified",
+	"err.dir.invalid_path":        "invalid path: %v",
+	"err.dir.not_empty":           "folder %s is not empty and does not contain the game",
+	"err.dir.not_a_dir":           "%s is not a folder",
+	"err.dir.read":                "failed to read folder %s: %v",
+	"err.dir.unavailable":         "folder %s is unavailable: %v",
+	"err.dir.not_writable":        "folder is not writable",
+	"err.dir.not_writable_path":   "%v: %s",
+	"err.dir.game_not_writable":   "game folder %s is not writable: the game cannot be installed or updated, and logs cannot be saved",
+	"launcher.manual_update_hint": "Launcher folder %s is not writable, automatic updates are disabled. Download the new version from %s and replace %s manually, or update the package the launcher was installed from",
+	"err.move.same_dir":           "the game is already in this folder",
+	"err.move.nested":             "the game cannot be moved into a nested or parent folder",
+	"err.move.target_has_game":    "the game is already installed in %s",
+	"err.move.read_src":           "failed to read folder %s: %v",
+	"err.move.incomplete":         "the game installation is not complete, it cannot be moved",
+	"err.move.create_dst":         "failed to create folder %s: %v",
+	"move.moving_files":           "Moving game files...",
+	"move.checking_moved":         "Checking moved files...",
+	"move.done":                   "Move complete",
+	"move.checking_copied":        "Checking copied files...",
+	"move.removing_old":           "Removing files from the old location...",
+	"move.remove_old_failed":      "Not all files were removed from the old location: %v",
+	"err.move.rename":             "failed to move %s: %v",
+	"err.move.copy":               "failed to copy %s: %v",
+	"move.copied":                 "Copied: %.1f MB / %.1f MB",
+	"err.move.not_copied":         "%v: file %s was not copied",
+	"err.move.type_mismatch":      "%v: file type of %s does not match",
+	"err.move.size_mismatch":      "%v: file size of %s does not match",
+	"err.move.content_mismatch":   "%v: file contents of %s do not match",
+	"location.logo":               "🚢 SUBMARINE INSTALLATION 🚢",
+	"location.title":              "WHERE TO INSTALL THE GAME?",
+	"location.hint":               "The game needs about 10 GB. In a non-empty folder the game is installed into the %s subfolder",
+	"location.footer":             "Enter - install here • Ctrl+U - clear • Esc - back",
+	"move.preparing":              "Preparing to move...",
+	"move.logo":                   "🚢 MOVING SUBMARINE 🚢",
+	"move.in_progress":            "%s Moving game files...",
+	"move.completed":              "✅ The game was moved!",
+	"move.error":                  "❌ Move failed",
+	"move.stayed":                 "The game stayed in place",
+	"move.cancelling":             "%s Cancelling the move, removing copied files...",
+	"move.cancelled":              "🛑 Move cancelled",
+	"move.confirm_cancel":         "Abort the move?",
+	"ui.footer_cancel":            "Esc/Q/Ctrl+C - cancel",
+	"err.move.game_running":       "the game is running, it cannot be moved",
+	"move.remember_failed":        "The game was moved, but the new folder could not be saved in settings: %v",
+	"relocate.use_dir":            "📂 Use %s",
+	"relocate.choose_dir":         "✏️  Choose another folder",
+	"relocate.hint":               "The launcher can keep the game, logs and downloads in a writable folder",
+	"relocate.not_moved":          "The game was not moved: %v",
+
+	// Установка и обновление игры
+	"install.preparing":           "Preparing...",
+	"install.logo":                "🚢 SUBMARINE INSTALLATION 🚢",
+	"install.state_preparing":     "%s Preparing to install...",
+	"install.state_downloading":   "%s Downloading the game...",
+	"install.state_extracting":    "%s Extracting files...",
+	"install.completed":           "✅ Installation completed successfully!",
+	"install.error":               "❌ Installation failed",
+	"install.cancelling":          "%s Cancelling the installation, removing downloaded data...",
+	"install.cancelled":           "🛑 Installation cancelled",
+	"install.cancelled_hint":      "Downloaded data was removed, installed files are unchanged",
+	"install.confirm_cancel":      "Abort the installation?",
+	"ui.cancel_confirm_hint":      "y - abort, n/Esc - continue",
+	"install.creating_dir":        "Creating the folder...",
+	"install.creating_game_dir":   "Creating the game folder...",
+	"install.starting_download":   "Starting the download...",
+	"install.done":                "Installation complete!",
+	"err.install.no_permission":   "no permission to create the game folder %s, choose another folder in settings",
+	"err.install.open_resumed":    "failed to open the archive of the interrupted installation: %v",
+	"err.install.clean_staging":   "failed to clean the staging folder: %v",
+	"install.preparing_download":  "Preparing to download...",
+	"err.install.create_archive":  "failed to create the temporary archive file: %v",
+	"install.resuming":            "Resuming the interrupted download (%.1f MB)...",
+	"install.downloading_archive": "Downloading the game archive...",
+	"err.install.download":        "failed to download the archive: %v",
+	"install.extracting":          "Extracting game files...",
+	"err.install.extract":         "failed to extract the archive: %v",
+	"install.validating":          "Checking game files...",
+	"install.applying":            "Installing new files...",
+	"err.read_dir":                "failed to read folder %s: %v",
+	"err.install.move_file":       "failed to move file %s: %v",
+	"err.remove_file":             "failed to remove file %s: %v",
+	"update.preparing":            "Preparing to update...",
+	"update.logo":                 "🚢 SUBMARINE UPDATE 🚢",
+	"update.state_preparing":      "%s Preparing to update...",
+	"update.state_downloading":    "%s Downloading the update...",
+	"update.state_extracting":     "%s Installing the update...",
+	"update.completed":            "✅ Update completed successfully!",
+	"update.autostart":            "The game will start automatically...",
+	"update.error":                "❌ Update failed",
+	"update.cancelling":           "%s Cancelling the update, removing downloaded data...",
+	"update.cancelled":            "🛑 Update cancelled",
+	"update.cancelled_hint":       "Downloaded data was removed, the installed game version is unchanged",
+	"update.confirm_cancel":       "Abort the update?",
+	"update.cleaning":             "Cleaning up old files...",
+	"update.starting":             "Starting the update...",
+	"update.done":                 "Update complete!",
+
+	// Обновление лаунчера
+	"self_update.preparing_download": "Preparing to download...",
+	"self_update.downloading":        "Downloading the new version...",
+	"self_update.creating_script":    "Creating the update script...",
+	"err.self_update.create_script":  "failed to create the script: %v",
+	"self_update.running_script":     "Running the update script...",
+	"err.self_update.run_script":     "failed to run the script: %v",
+	"self_update.done":               "Update complete!",
+	"self_update.connecting":         "Connecting to the server...",
+	"err.self_update.download":       "failed to download the update: %v",
+	"err.self_update.status":         "unexpected response status %d while downloading the update",
+	"self_update.starting_file":      "Starting the file download...",
+	"err.create_file":                "failed to create the file: %v",
+	"err.write_file":                 "failed to write the file: %v",
+	"self_update.downloaded_mb":      "Downloaded: %.1f MB",
+	"err.read_file":                  "failed to read the file: %v",
+	"self_update.download_done":      "Download complete!",
+	"self_update.downloading_update": "Downloading the update...",
+	"self_update.downloaded":         "Update downloaded successfully!",
+	"self_update.applying":           "Applying the update...",
+	"self_update.started_restart":    "Update started! The launcher will restart...",
+	"self_update.started_no_restart": "Update started! The launcher file will be replaced after exit",
+	"self_update.preparing":          "Preparing to update the launcher...",
+	"self_update.logo":               "🚀 LAUNCHER UPDATE 🚀",
+	"self_update.state_preparing":    "%s Preparing to update the launcher...",
+	"self_update.state_downloading":  "%s Downloading the new launcher version...",
+	"self_update.state_installing":   "%s Installing the launcher update...",
+	"self_update.completed":          "✅ Launcher update complete!",
+	"self_update.restart":            "The launcher will restart automatically...",
+	"self_update.error":              "❌ Launcher update failed",
+	"self_update.initializing":       "Initializing the update...",
+
+	// Загрузка и распаковка
+	"err.download.create_dir":   "failed to create the downloads folder: %v",
+	"update.removed":            "Removed: %s",
+	"err.update.remove_old":     "failed to remove old files: %v",
+	"update.remove_temp_failed": "Failed to remove the temporary archive file: %v",
+	"err.server_status":         "the server returned status %d",
+	"download.progress_label":   "📦 Downloading",
+	"err.download.read":         "failed to read data: %v",
+	"download.unpacking":        "Extracting the archive...",
+	"download.unpack_label":     "📦 Extracting",
+	"download.unpacked":         "Extraction complete!",
+	"download.progress":         "Downloaded: %.1f MB / %.1f MB",
+	"download.unpack_progress":  "Extracting: %d/%d files",
+	"err.hash_mismatch":         "archive hash does not match",
+
+	// Уборка и восстановление
+	"cleanup.empty_file":          "empty file",
+	"cleanup.too_old":             "download is too old",
+	"cleanup.no_meta":             "no download description",
+	"cleanup.other_archive":       "archive for another platform or address",
+	"cleanup.no_resume":           "the server does not support resuming downloads",
+	"cleanup.complete":            "download is already complete",
+	"cleanup.resumable":           "interrupted download",
+	"cleanup.orphan_meta_removed": "description of a removed archive",
+	"cleanup.orphan_meta":         "description without an archive",
+	"cleanup.new_launcher":        "unfinished self-update",
+	"cleanup.old_launcher":        "old launcher version",
+	"cleanup.update_script":       "script of an interrupted self-update",
+	"err.journal.parse":           "failed to parse the install journal: %v",
+	"err.journal.write":           "failed to write the install journal: %v",
+	"err.journal.apply":           "failed to install new files: %v",
+	"journal.unrecoverable":       "The interrupted installation cannot be recovered, the game must be reinstalled",
+	"journal.recovered":           "The interrupted installation was completed",
+	"journal.resume":              "The game installation was interrupted and will be resumed",
+	"err.journal.remove_staging":  "failed to remove the staging folder: %v",
+
+	// Блокировка папки игры
+	"err.lock.locked":       "the game folder is used by another launcher instance",
+	"err.lock.create_dir":   "failed to create the game folder: %v",
+	"err.lock.write":        "failed to write the lock file: %v",
+	"err.lock.create":       "failed to create the lock file: %v",
+	"err.lock.remove_stale": "failed to remove the stale lock: %v",
+	"err.lock.acquire":      "failed to acquire the lock %s",
+	"err.lock.parse":        "failed to parse the lock file: %v",
+	"lock.update_failed":    "Failed to update the lock file: %v",
+	"lock.wait":             "⏳ Wait for it to finish",
+	"lock.read_only":        "👁️  View only",
+	"lock.waiting":          "%s Waiting for the other launcher to exit...",
+	"lock.footer_waiting":   "Esc/Q - stop waiting",
+	"lock.holder":           "The game is already managed by another launcher instance",
+	"lock.holder_details":   "The game is already managed by another launcher instance (PID %d, started %s)",
+
+	// Проверка установки
+	"err.validate.failed":          "installation check failed",
+	"err.validate.problems":        "%v:\n- %s",
+	"validate.version_unreadable":  "version file %s cannot be read: %v",
+	"validate.version_empty":       "no version in file %s",
+	"validate.version_mismatch":    "installed version is %s, expected %s",
+	"validate.required_missing":    "required file %s is missing",
+	"validate.required_unsafe":     "required file %s is outside the game folder",
+	"validate.executable_missing":  "game executable not found: %s",
+	"validate.executable_not_file": "game executable is not a file: %s",
+	"validate.executable_empty":    "game executable is empty: %s",
+	"validate.chmod_failed":        "failed to make the game file executable: %v",
+
+	// Версии и манифест
+	"err.version.format":           "invalid version format: %s",
+	"err.localized_text.format":    "line %d: text must be a string or a map of languages",
+	"err.time.parse":               "failed to parse time: %s",
+	"err.manifest.request":         "failed to request the version: %v",
+	"err.manifest.times":           "invalid time in the manifest: %v",
+	"err.manifest.read":            "failed to read the server response: %v",
+	"err.manifest.parse":           "failed to parse YAML: %v",
+	"err.version_file.read":        "failed to read the version file: %v",
+	"err.version_file.parse":       "failed to parse the game version YAML: %v",
+	"maintenance.scheduled":        "Attention! Maintenance starts in %s (%s), the game will be unavailable during it",
+	"maintenance.scheduled_window": "Attention! Maintenance starts in %s (%s - %s), the game will be unavailable during it",
+	"maintenance.active_until":     "Attention! Maintenance is in progress, the game is unavailable. Expected to end in %s (%s)",
+	"maintenance.with_reason":      "%s. Reason: %s",
+	"countdown.days":               "%dd %dh",
+	"countdown.hours":              "%dh %02dm",
+	"countdown.minutes":            "%dm %02ds",
+	"countdown.seconds":            "%ds",
+	"maintenance.active":           "Attention! Maintenance is in progress, the game is unavailable",
+
+	// News
+	"news.title":        "📰 News",
+	"news.title_unread": "📰 News • unread: %d",
+	"news.more_above":   "↑ %d more",
+	"news.more_below":   "↓ %d more",
+	"news.published":    "%s, %s",
+	"news.expires":      "Relevant until %s (%s)",
+	"news.open_hint":    "Tab - read the news",
+	"news.footer":       "↑/↓ - scroll news • Tab/Esc - back to menu • Q - exit",
+	"err.news.write":    "failed to save read news: %v",
+
+	// Manifest cache
+	"err.manifest_cache.missing":       "no saved manifest",
+	"err.manifest_cache.read":          "failed to read the saved manifest: %v",
+	"err.manifest_cache.write":         "failed to save the manifest: %v",
+	"err.manifest_cache.schema":        "the saved manifest uses format %d, %d is required",
+	"err.manifest_cache.other_channel": "the saved manifest belongs to another channel: %s",
+	"err.manifest_cache.checksum":      "the saved manifest checksum does not match",
+	"launcher.offline_cached":          "The server is unavailable, showing data from %s",
+	"cli.status.manifest_cache":        "Manifest cache:    %s",
+	"cli.status.no_manifest_cache":     "Manifest cache:    none (%s)",
+
+	// Clock
+	"clock.behind":          "The computer clock is %s behind the server clock. Maintenance and news use server time",
+	"clock.ahead":           "The computer clock is %s ahead of the server clock. Maintenance and news use server time",
+	"cli.status.clock_skew": "Clock:             %s",
+
+	// Time
+	"time.in":               "in %s",
+	"time.ago":              "%s ago",
+	"err.time.unknown_zone": "unknown time zone: %s",
+	"err.time.no_zone":      "time %s has no offset and the manifest does not set timezone",
+	"err.time.nonexistent":  "time %s does not exist in %s: clocks move forward",
+	"err.time.ambiguous":    "time %s is ambiguous in %s: clocks move back, specify the offset",
+
+	// Mandatory and postponed updates
+	"update_prompt.launcher":          "Launcher version %s is available (you have %s)",
+	"update_prompt.game":              "Game version %s is available (you have %s)",
+	"update_prompt.now":               "🔄 Update now",
+	"update_prompt.later_days":        "⏰ Remind me in %d days",
+	"update_prompt.later_next_start":  "⏰ Remind me next time",
+	"update_prompt.skip":              "⏭️  Skip this version",
+	"update_prompt.footer":            "↑/↓ - navigate • Enter - select • Esc - remind me later",
+	"updates.remember_failed":         "Failed to remember the choice: %v",
+	"err.updates.write":               "failed to save postponed updates: %v",
+	"launcher.update_required":        "Launcher version %s is no longer supported, updating to %s is required",
+	"launcher.update_required_manual": "Launcher version %s is no longer supported, version %s or newer is required. %s",
+	"menu.game_update_required":       "🔴 The game must be updated to version %s",
+	"cli.launcher_update_required":    "Launcher version %s is no longer supported, version %s or newer is required: run self-update",
+	"cli.game_update_required":        "Game version %s is no longer supported, version %s or newer is required: run update",
+
+	// Release notes before updating
+	"release_notes.title":     "Update %s → %s",
+	"release_notes.size":      "download %s",
+	"release_notes.version":   "Version %s",
+	"release_notes.position":  "lines %d-%d of %d",
+	"release_notes.footer":    "↑/↓, PgUp/PgDn - scroll • Enter - update • Esc - back",
+	"size.mb":                 "%.1f MB",
+	"size.gb":                 "%.1f GB",
+	"err.update_size_unknown": "the server did not report the archive size",
+
+	// Staged rollouts
+	"err.install_id.read":  "failed to read the installation ID: %v",
+	"err.install_id.write": "failed to save the installation ID: %v",
+
+	// Blocked launcher versions
+	"launcher.blocked.force_update":         "Launcher version %s has a critical bug and must be updated",
+	"launcher.blocked.disable_game_updates": "Launcher version %s has a bug: installing and updating the game is disabled until the launcher is updated",
+	"launcher.blocked.warn":                 "Launcher version %s has a bug, updating the launcher is recommended",
+	"launcher.blocked_no_update":            "%s. A fixed version is not available yet, please try again later",
+	"launcher.blocked_manual":               "%s. %s",
+	"cli.launcher_blocked":                  "%s. Update the launcher with self-update",
+}
@@ -0,0 +1,474 @@
+package internal
+
+// messagesRu - русский каталог сообщений, основной: в нем есть все сообщения лаунчера
+var messagesRu = map[string]string{
+	// Главное меню и общие элементы интерфейса
+	"menu.run":                   "🎮 Запустить игру",
+	"menu.install":               "📦 Установить игру",
+	"menu.update":                "🔄 Обновить игру",
+	"menu.settings":              "⚙️  Настройки",
+	"menu.exit":                  "🚪 Выход",
+	"menu.retry":                 "🔄 Проверить снова",
+	"menu.logo":                  "🚢 СУБМАРИНА LAUNCHER 🚢",
+	"menu.read_only":             "👁️  Только просмотр. %s",
+	"menu.game_not_installed":    "🔴 Игра не установлена",
+	"menu.update_available":      "🟡 Доступно обновление",
+	"menu.game_ready":            "🟢 Игра готова к запуску",
+	"menu.title":                 "ВЫБЕРИТЕ ДЕЙСТВИЕ",
+	"menu.footer_news":           "↑/↓ - навигация • Tab - новости • Enter - выбрать • Esc/Q - выход",
+	"menu.maintenance_over":      "Техническое обслуживание закончилось, игра снова доступна",
+	"menu.game_update_found":     "Вышла новая версия игры %s",
+	"menu.launcher_update_found": "Вышла новая версия лаунчера %s, перезапустите лаунчер, чтобы обновиться",
+	"menu.footer":                "↑/↓ - навигация • Enter - выбрать • Esc/Q - выход",
+	"ui.confirm_no_terminal":     "%s (нет терминала, ответ по умолчанию: нет)",
+	"ui.confirm_hint":            "y/н для подтверждения, любая другая клавиша для отмены",
+	"ui.press_enter_to_exit":     "Нажмите Enter для выхода...",
+	"ui.press_enter_to_continue": "Нажмите Enter для продолжения",
+	"ui.interface_error":         "Ошибка интерфейса: %v",
+	"ui.goodbye":                 "Лаунчер закрыт! 👋",
+	"err.console_handle":         "не удалось получить handle консоли",
+	"err.console_mode_get":       "не удалось получить режим консоли",
+	"err.console_mode_set":       "не удалось установить режим консоли",
+
+	// Запуск лаунчера
+	"launcher.executable_path_error":         "Ошибка при получении пути к исполняемому файлу: %v",
+	"launcher.game_dir_overridden_read_only": "%v. Укажите другую папку в переменной SUBMARINE_GAME_DIR или флаге --game-dir",
+	"launcher.log_open_failed":               "Не удалось открыть журнал лаунчера: %v",
+	"launcher.update_check_failed":           "Не удалось проверить обновления лаунчера: %v",
+	"launcher.update_manual":                 "Доступна версия лаунчера %s. %s",
+	"launcher.update_found":                  "Найдено обновление лаунчера: %s → %s",
+	"launcher.update_failed":                 "Ошибка при обновлении лаунчера: %v",
+	"launcher.lock_failed":                   "Ошибка при блокировке директории игры: %v",
+	"launcher.recover_failed":                "Ошибка при восстановлении прерванной установки: %v",
+	"launcher.lock_check_failed":             "Не удалось проверить блокировку: %v",
+	"launcher.switch_dir_failed":             "Не удалось переключиться на новую папку игры: %v",
+	"launcher.manifest_failed":               "Не удалось получить манифест: %v",
+	"launcher.game_running":                  "Игра уже запущена, дождитесь ее завершения",
+	"launcher.maintenance_blocked":           "Игра недоступна из-за технического обслуживания",
+	"launcher.choose_dir_failed":             "Не удалось выбрать папку установки: %v",
+	"launcher.remember_dir_failed":           "Не удалось сохранить папку игры в настройках: %v",
+	"launcher.run_failed":                    "Ошибка при запуске игры: %v",
+
+	// Настройки
+	"settings.game_dir.title":         "Папка игры",
+	"settings.game_dir.hint":          "пусто - папка рядом с лаунчером",
+	"settings.channel.title":          "Канал обновлений",
+	"settings.channel.hint":           "stable, beta или другой канал сервера",
+	"settings.language.title":         "Язык",
+	"settings.language.hint":          "язык интерфейса, auto - по LANG и LC_ALL",
+	"settings.bandwidth.title":        "Ограничение скорости, КБ/с",
+	"settings.bandwidth.hint":         "0 - без ограничения",
+	"settings.log_retention.title":    "Хранить логи игры, дней",
+	"settings.log_retention.hint":     "0 - хранить все логи",
+	"settings.manifest_refresh.title": "Проверять обновления в меню, минут",
+	"settings.manifest_refresh.hint":  "0 - только при запуске лаунчера",
+	"settings.update_reminder.title":  "Напомнить об отложенном обновлении, дней",
+	"settings.update_reminder.hint":   "0 - при следующем запуске лаунчера",
+	"settings.launch_args.title":      "Аргументы запуска игры",
+	"settings.launch_args.hint":       "через пробел, передаются игре после -launcher",
+	"settings.theme.title":            "Тема",
+	"settings.theme.hint":             "оформление интерфейса",
+	"settings.flag_usage":             "%s: %s",
+	"err.settings.invalid_path":       "неверный путь: %v",
+	"err.settings.not_a_dir":          "%s не является папкой",
+	"err.settings.invalid_channel":    "недопустимое имя канала: %s",
+	"err.settings.unknown_language":   "неизвестный язык: %s (допустимо: auto, ru, en)",
+	"err.settings.unknown_theme":      "неизвестная тема: %s (допустимо: dark, light)",
+	"err.settings.not_non_negative":   "ожидается целое число не меньше 0: %s",
+	"err.settings.config_dir":         "не удалось определить папку конфигурации: %v",
+	"err.settings.read":               "ошибка при чтении настроек: %v",
+	"err.settings.parse":              "ошибка при разборе настроек: %v",
+	"err.settings.field":              "%s: %v",
+	"err.settings.create_dir":         "ошибка при создании папки настроек: %v",
+	"err.settings.write":              "ошибка при записи настроек: %v",
+	"err.settings.extra_args":         "лишние аргументы: %s",
+	"settings.source_flag":            "флаг --%s",
+	"settings.source_env":             "переменная %s",
+	"settings.defaults_used":          "Используются настройки по умолчанию: %v",
+	"settings.move_rejected":          "Нельзя перенести игру: %v",
+	"settings.bad_game_dir":           "Неподходящая папка игры: %v",
+	"settings.save_failed":            "Не удалось сохранить настройки: %v",
+	"settings.saved":                  "Настройки сохранены",
+	"settings.title":                  "НАСТРОЙКИ",
+	"settings.overridden":             "  (сейчас задано: %s)",
+	"settings.save":                   "💾 Сохранить",
+	"settings.back":                   "↩️  Назад",
+	"settings.footer_editing":         "Enter - применить • Esc - отменить • Ctrl+U - очистить",
+	"settings.footer":                 "↑/↓ - навигация • ←/→ - выбор • Enter - изменить • Esc/Q - назад",
+
+	// Командная строка и текстовый режим
+	"cli.not_installed":              "Игра не установлена",
+	"cli.create_dir_failed":          "Ошибка при создании директории игры: %v",
+	"cli.verify_ok":                  "Файлы игры в порядке: %s",
+	"cli.version_mismatch":           "Установлена версия %s, а --expect-version требует %s",
+	"cli.install_incomplete":         "Установка игры не завершена",
+	"cli.move_target_required":       "Укажите новую папку игры: --to <путь>",
+	"cli.removed":                    "Удалено: %s",
+	"cli.move_cancelled":             "Перенос отменен, игра осталась на прежнем месте",
+	"cli.moving":                     "Перенос игры: %s → %s",
+	"cli.cancelled":                  "Операция отменена, установленные файлы не изменены",
+	"plain.updating":                 "Обновляем игру: %s → %s",
+	"cli.self_updating":              "Обновление лаунчера: %s → %s",
+	"cli.nothing_to_clean":           "Нечего удалять",
+	"cli.server_check_failed":        "Не удалось проверить состояние сервера: %v",
+	"cli.update_check_failed":        "Не удалось проверить наличие обновлений: %v",
+	"cli.launcher_up_to_date":        "Лаунчер актуален: %s",
+	"cli.already_installed":          "Игра уже установлена: %s",
+	"cli.already_running":            "Игра уже запущена",
+	"cli.moved":                      "Игра перенесена в %s",
+	"plain.installing":               "Игра не установлена, устанавливаем версию %s",
+	"plain.no_server":                "Игра не установлена, а сервер обновлений недоступен",
+	"cli.up_to_date":                 "Игра актуальна: %s",
+	"cli.update_available":           "Доступно обновление: %s → %s",
+	"plain.launcher_update":          "Доступно обновление лаунчера: %s → %s. Для установки выполните команду self-update",
+	"plain.launcher_update_manual":   "Доступно обновление лаунчера: %s → %s. %s",
+	"cli.launch_outdated":            "Доступно обновление %s, запускаем установленную версию %s",
+	"cli.server_version_mismatch":    "На сервере версия %s, а --expect-version требует %s",
+	"plain.game_dir_read_only":       "%v. Перенесите игру командой move --to <путь> или укажите другую папку флагом --game-dir",
+	"plain.fallback_dir_failed":      "%v. Не удалось выбрать другую папку: %v",
+	"plain.fallback_dir":             "%v. Игра будет установлена в %s",
+	"cli.move_read_only":             "%v. Игра будет скопирована, старые файлы останутся на месте",
+	"cli.error":                      "%v",
+	"cli.cmd.install":                "установить игру",
+	"cli.cmd.update":                 "обновить установленную игру",
+	"cli.cmd.verify":                 "проверить файлы установленной игры",
+	"cli.cmd.launch":                 "запустить игру и дождаться ее завершения",
+	"cli.cmd.status":                 "показать состояние игры и сервера",
+	"cli.cmd.self_update":            "обновить лаунчер без перезапуска",
+	"cli.cmd.move":                   "перенести установленную игру в другую папку",
+	"cli.cmd.clean":                  "удалить остатки прерванных операций",
+	"cli.unknown_command":            "Неизвестная команда: %s",
+	"cli.flag.expect_version":        "завершиться с кодом 9, если версия игры другая (лаунчер всегда ставит версию с сервера)",
+	"cli.flag.json":                  "выводить результат в формате JSON",
+	"cli.flag.force":                 "переустановить, даже если игра актуальна",
+	"cli.flag.to":                    "новая папка игры",
+	"cli.extra_args":                 "Лишние аргументы: %s",
+	"cli.usage":                      "Использование: SubmarineLauncher [команда] [флаги]\nБез команды запускается интерактивный лаунчер, флаги настроек работают и в нем.\n\nКоманды:",
+	"cli.usage_flags":                "Флаги:\n  --version <версия>  ожидаемая версия игры\n  --force             переустановить игру (install, update)\n  --to <путь>         новая папка игры (move)\n  --json              JSON для status, события NDJSON для остальных команд\n  --plain             построчный вывод без цветов и вопросов (также NO_COLOR или вывод не в терминал)\n\nНастройки (переопределяют файл настроек, переменные окружения - в скобках):",
+	"cli.done":                       "Готово",
+	"cli.status.game_dir":            "Папка игры:        %s",
+	"cli.status.installed":           "Установлена:       %s",
+	"cli.status.not_installed":       "Установлена:       нет",
+	"cli.status.launcher":            "Лаунчер:           %s",
+	"cli.status.remote_game":         "Доступна игра:     %s",
+	"cli.status.remote_launcher":     "Доступен лаунчер:  %s",
+	"cli.status.update_required":     "Обновление игры:   требуется",
+	"cli.status.update_not_required": "Обновление игры:   не требуется",
+	"cli.status.maintenance":         "Обслуживание:      %s",
+	"cli.status.no_maintenance":      "Обслуживание:      нет",
+	"cli.status.server_message":      "Сообщение сервера: %s",
+	"plain.level.error":              "[ОШИБКА]",
+	"plain.level.warn":               "[ВНИМАНИЕ]",
+	"plain.level.success":            "[ГОТОВО]",
+	"plain.level.info":               "[ИНФО]",
+
+	// Запуск игры
+	"game.starting":                 "Запуск игры...",
+	"game.executable_not_found":     "Исполняемый файл игры не найден: %s",
+	"err.game.executable_not_found": "исполняемый файл игры не найден: %s",
+	"game.codesign_failed":          "Не удалось удалить подпись кода: %v",
+	"game.codesign_removed":         "Подпись кода удалена",
+	"game.chmod_failed":             "Ошибка при установке прав на выполнение: %v",
+	"game.tts_chmod_failed":         "Не удалось установить права на выполнение TTS: %v",
+	"game.tts_check_failed":         "Ошибка при проверке TTS: %v",
+	"game.log_dir_fallback":         "Папка логов %s недоступна для записи, логи сохраняются в %s",
+	"game.log_create_failed":        "Не удалось создать лог-файл: %v",
+	"game.log_started":              "=== Лог игры начат: %s ===",
+	"game.log_path":                 "Путь к игре: %s",
+	"game.log_location":             "Лог игры записывается в: %s",
+	"game.started":                  "Игра запущена: %s",
+	"game.log_finished":             "=== Лог игры завершен: %s ===",
+	"game.exited_with_error":        "Игра завершилась с ошибкой: %v",
+	"game.exited":                   "Игра завершена",
+	"err.game.version_check":        "ошибка при проверке версии игры: %v",
+	"err.game.version_read":         "ошибка при чтении файла с версией: %v",
+
+	// Папка игры и перенос
+	"err.dir.not_specified":       "папка не указана",
+	"err.dir.invalid_path":        "неверный путь: %v",
+	"err.dir.not_empty":           "папка %s не пуста и не содержит игру",
+	"err.dir.not_a_dir":           "%s не является папкой",
+	"err.dir.read":                "ошибка при чтении папки %s: %v",
+	"err.dir.unavailable":         "папка %s недоступна: %v",
+	"err.dir.not_writable":        "папка недоступна для записи",
+	"err.dir.not_writable_path":   "%v: %s",
+	"err.dir.game_not_writable":   "папка игры %s недоступна для записи: игру нельзя установить или обновить, а логи - сохранить",
+	"launcher.manual_update_hint": "Папка лаунчера %s недоступна для записи, автообновление отключено. Скачайте новую версию с %s и замените ею %s вручную или обновите пакет, из которого установлен лаунчер",
+	"err.move.same_dir":           "игра уже находится в этой папке",
+	"err.move.nested":             "нельзя переносить игру во вложенную или родительскую папку",
+	"err.move.target_has_game":    "в папке %s уже установлена игра",
+	"err.move.read_src":           "ошибка при чтении директории %s: %v",
+	"err.move.incomplete":         "установка игры не завершена, перенос невозможен",
+	"err.move.create_dst":         "ошибка при создании папки %s: %v",
+	"move.moving_files":           "Перенос файлов игры...",
+	"move.checking_moved":         "Проверка перенесенных файлов...",
+	"move.done":                   "Перенос завершен",
+	"move.checking_copied":        "Проверка скопированных файлов...",
+	"move.removing_old":           "Удаление файлов со старого места...",
+	"move.remove_old_failed":      "Не все файлы удалены со старого места: %v",
+	"err.move.rename":             "ошибка при переносе %s: %v",
+	"err.move.copy":               "ошибка при копировании %s: %v",
+	"move.copied":                 "Скопировано: %.1f MB / %.1f MB",
+	"err.move.not_copied":         "%v: файл %s не скопирован",
+	"err.move.type_mismatch":      "%v: тип файла %s не совпадает",
+	"err.move.size_mismatch":      "%v: размер файла %s не совпадает",
+	"err.move.content_mismatch":   "%v: содержимое файла %s не совпадает",
+	"location.logo":               "🚢 УСТАНОВКА СУБМАРИНЫ 🚢",
+	"location.title":              "КУДА УСТАНОВИТЬ ИГРУ?",
+	"location.hint":               "Для игры нужно около 10 GB. В непустую папку игра будет установлена в подпапку %s",
+	"location.footer":             "Enter - установить сюда • Ctrl+U - очистить • Esc - назад",
+	"move.preparing":              "Подготовка к переносу...",
+	"move.logo":                   "🚢 ПЕРЕНОС СУБМАРИНЫ 🚢",
+	"move.in_progress":            "%s Перенос файлов игры...",
+	"move.completed":              "✅ Игра перенесена!",
+	"move.error":                  "❌ Ошибка переноса",
+	"move.stayed":                 "Игра осталась на прежнем месте",
+	"move.cancelling":             "%s Отмена переноса, удаляем скопированные файлы...",
+	"move.cancelled":              "🛑 Перенос отменен",
+	"move.confirm_cancel":         "Прервать перенос?",
+	"ui.footer_cancel":            "Esc/Q/Ctrl+C - отменить",
+	"err.move.game_running":       "игра запущена, перенос невозможен",
+	"move.remember_failed":        "Игра перенесена, но новую папку не удалось сохранить в настройках: %v",
+	"relocate.use_dir":            "📂 Использовать %s",
+	"relocate.choose_dir":         "✏️  Выбрать другую папку",
+	"relocate.hint":               "Лаунчер может хранить игру, логи и загрузки в папке, доступной для записи",
+	"relocate.not_moved":          "Игра не перенесена: %v",
+
+	// Установка и обновление игры
+	"install.preparing":           "Подготовка...",
+	"install.logo":                "🚢 УСТАНОВКА СУБМАРИНЫ 🚢",
+	"install.state_preparing":     "%s Подготовка к установке...",
+	"install.state_downloading":   "%s Загрузка игры...",
+	"install.state_extracting":    "%s Распаковка файлов...",
+	"install.completed":           "✅ Установка завершена успешно!",
+	"install.error":               "❌ Ошибка установки",
+	"install.cancelling":          "%s Отмена установки, удаляем загруженные данные...",
+	"install.cancelled":           "🛑 Установка отменена",
+	"install.cancelled_hint":      "Загруженные данные удалены, установленные файлы не изменены",
+	"install.confirm_cancel":      "Прервать установку?",
+	"ui.cancel_confirm_hint":      "y/н - прервать, n/т/Esc - продолжить",
+	"install.creating_dir":        "Создание директории...",
+	"install.creating_game_dir":   "Создание директории игры...",
+	"install.starting_download":   "Начало загрузки...",
+	"install.done":                "Установка завершена!",
+	"err.install.no_permission":   "нет прав на создание папки игры %s, выберите другую папку в настройках",
+	"err.install.open_resumed":    "ошибка при открытии архива прерванной установки: %v",
+	"err.install.clean_staging":   "ошибка при очистке промежуточной папки: %v",
+	"install.preparing_download":  "Подготовка к загрузке...",
+	"err.install.create_archive":  "ошибка при создании временного файла архива: %v",
+	"install.resuming":            "Продолжаем прерванную загрузку (%.1f MB)...",
+	"install.downloading_archive": "Загрузка архива игры...",
+	"err.install.download":        "ошибка при загрузке архива: %v",
+	"install.extracting":          "Распаковка файлов игры...",
+	"err.install.extract":         "ошибка при распаковке архива: %v",
+	"install.validating":          "Проверка файлов игры...",
+	"install.applying":            "Установка новых файлов...",
+	"err.read_dir":                "ошибка при чтении директории %s: %v",
+	"err.install.move_file":       "ошибка при переносе файла %s: %v",
+	"err.remove_file":             "ошибка при удалении файла %s: %v",
+	"update.preparing":            "Подготовка к обновлению...",
+	"update.logo":                 "🚢 ОБНОВЛЕНИЕ СУБМАРИНЫ 🚢",
+	"update.state_preparing":      "%s Подготовка к обновлению...",
+	"update.state_downloading":    "%s Загрузка обновления...",
+	"update.state_extracting":     "%s Установка обновления...",
+	"update.completed":            "✅ Обновление завершено успешно!",
+	"update.autostart":            "Игра будет запущена автоматически...",
+	"update.error":                "❌ Ошибка обновления",
+	"update.cancelling":           "%s Отмена обновления, удаляем загруженные данные...",
+	"update.cancelled":            "🛑 Обновление отменено",
+	"update.cancelled_hint":       "Загруженные данные удалены, установленная версия игры не изменена",
+	"update.confirm_cancel":       "Прервать обновление?",
+	"update.cleaning":             "Очистка старых файлов...",
+	"update.starting":             "Начало обновления...",
+	"update.done":                 "Обновление завершено!",
+
+	// Обновление лаунчера
+	"self_update.preparing_download": "Подготовка к загрузке...",
+	"self_update.downloading":        "Начинаем загрузку новой версии...",
+	"self_update.creating_script":    "Создание скрипта обновления...",
+	"err.self_update.create_script":  "ошибка при создании скрипта: %v",
+	"self_update.running_script":     "Запуск скрипта обновления...",
+	"err.self_update.run_script":     "ошибка при запуске скрипта: %v",
+	"self_update.done":               "Обновление завершено!",
+	"self_update.connecting":         "Подключение к серверу...",
+	"err.self_update.download":       "ошибка при загрузке обновления: %v",
+	"err.self_update.status":         "неожиданный статус ответа %d при загрузке обновления",
+	"self_update.starting_file":      "Начинаем загрузку файла...",
+	"err.create_file":                "ошибка при создании файла: %v",
+	"err.write_file":                 "ошибка при записи файла: %v",
+	"self_update.downloaded_mb":      "Загружено: %.1f MB",
+	"err.read_file":                  "ошибка при чтении файла: %v",
+	"self_update.download_done":      "Загрузка завершена!",
+	"self_update.downloading_update": "Загрузка обновления...",
+	"self_update.downloaded":         "Обновление скачано успешно!",
+	"self_update.applying":           "Выполняем обновление...",
+	"self_update.started_restart":    "Обновление запущено! Лаунчер перезапустится...",
+	"self_update.started_no_restart": "Обновление запущено! Файл лаунчера будет заменен после выхода",
+	"self_update.preparing":          "Подготовка к обновлению лаунчера...",
+	"self_update.logo":               "🚀 ОБНОВЛЕНИЕ ЛАУНЧЕРА 🚀",
+	"self_update.state_preparing":    "%s Подготовка к обновлению лаунчера...",
+	"self_update.state_downloading":  "%s Загрузка новой версии лаунчера...",
+	"self_update.state_installing":   "%s Установка обновления лаунчера...",
+	"self_update.completed":          "✅ Обновление лаунчера завершено!",
+	"self_update.restart":            "Лаунчер будет перезапущен автоматически...",
+	"self_update.error":              "❌ Ошибка обновления лаунчера",
+	"self_update.initializing":       "Инициализация обновления...",
+
+	// Загрузка и распаковка
+	"err.download.create_dir":   "ошибка при создании папки загрузок: %v",
+	"update.removed":            "Удалено: %s",
+	"err.update.remove_old":     "ошибка при удалении старых файлов: %v",
+	"update.remove_temp_failed": "Ошибка при удалении временного файла архива: %v",
+	"err.server_status":         "сервер вернул статус %d",
+	"download.progress_label":   "📦 Загружаем",
+	"err.download.read":         "ошибка при чтении данных: %v",
+	"download.unpacking":        "Распаковка архива...",
+	"download.unpack_label":     "📦 Распаковываем",
+	"download.unpacked":         "Распаковка завершена!",
+	"download.progress":         "Загружено: %.1f MB / %.1f MB",
+	"download.unpack_progress":  "Распаковка: %d/%d файлов",
+	"err.hash_mismatch":         "хеш архива не совпадает",
+
+	// Уборка и восстановление
+	"cleanup.empty_file":          "пустой файл",
+	"cleanup.too_old":             "загрузка слишком старая",
+	"cleanup.no_meta":             "нет описания загрузки",
+	"cleanup.other_archive":       "архив для другой платформы или адреса",
+	"cleanup.no_resume":           "сервер не поддерживает продолжение загрузки",
+	"cleanup.complete":            "загрузка уже завершена",
+	"cleanup.resumable":           "прерванная загрузка",
+	"cleanup.orphan_meta_removed": "описание удаленного архива",
+	"cleanup.orphan_meta":         "описание без архива",
+	"cleanup.new_launcher":        "незавершенное самообновление",
+	"cleanup.old_launcher":        "старая версия лаунчера",
+	"cleanup.update_script":       "скрипт прерванного самообновления",
+	"err.journal.parse":           "ошибка при разборе журнала установки: %v",
+	"err.journal.write":           "ошибка при записи журнала установки: %v",
+	"err.journal.apply":           "ошибка при установке новых файлов: %v",
+	"journal.unrecoverable":       "Прерванная установка не может быть восстановлена, игру нужно установить заново",
+	"journal.recovered":           "Прерванная установка завершена",
+	"journal.resume":              "Установка игры была прервана и будет продолжена",
+	"err.journal.remove_staging":  "ошибка при удалении промежуточной папки: %v",
+
+	// Блокировка папки игры
+	"err.lock.locked":       "директория игры используется другим экземпляром лаунчера",
+	"err.lock.create_dir":   "ошибка при создании директории игры: %v",
+	"err.lock.write":        "ошибка при записи файла блокировки: %v",
+	"err.lock.create":       "ошибка при создании файла блокировки: %v",
+	"err.lock.remove_stale": "ошибка при удалении устаревшей блокировки: %v",
+	"err.lock.acquire":      "не удалось захватить блокировку %s",
+	"err.lock.parse":        "ошибка при разборе файла блокировки: %v",
+	"lock.update_failed":    "Не удалось обновить файл блокировки: %v",
+	"lock.wait":             "⏳ Ждать завершения",
+	"lock.read_only":        "👁️  Только просмотр",
+	"lock.waiting":          "%s Ожидаем завершения другого лаунчера...",
+	"lock.footer_waiting":   "Esc/Q - прекратить ожидание",
+	"lock.holder":           "Игрой уже управляет другой экземпляр лаунчера",
+	"lock.holder_details":   "Игрой уже управляет другой экземпляр лаунчера (PID %d, запущен %s)",
+
+	// Проверка установки
+	"err.validate.failed":          "проверка установки не пройдена",
+	"err.validate.problems":        "%v:\n- %s",
+	"validate.version_unreadable":  "файл версии %s не читается: %v",
+	"validate.version_empty":       "в файле %s не указана версия",
+	"validate.version_mismatch":    "установлена версия %s, ожидалась %s",
+	"validate.required_missing":    "отсутствует обязательный файл %s",
+	"validate.required_unsafe":     "обязательный файл %s находится вне папки игры",
+	"validate.executable_missing":  "исполняемый файл игры не найден: %s",
+	"validate.executable_not_file": "исполняемый файл игры не является файлом: %s",
+	"validate.executable_empty":    "исполняемый файл игры пуст: %s",
+	"validate.chmod_failed":        "не удалось сделать файл игры исполняемым: %v",
+
+	// Версии и манифест
+	"err.version.format":           "неверный формат версии: %s",
+	"err.localized_text.format":    "строка %d: текст должен быть строкой или словарем языков",
+	"err.time.parse":               "не удалось разобрать время: %s",
+	"err.manifest.request":         "ошибка при запросе версии: %v",
+	"err.manifest.times":           "ошибка во времени в манифесте: %v",
+	"err.manifest.read":            "ошибка при чтении ответа с сервера: %v",
+	"err.manifest.parse":           "ошибка при разборе YAML: %v",
+	"err.version_file.read":        "ошибка при чтении файла версии: %v",
+	"err.version_file.parse":       "ошибка при разборе YAML версии игры: %v",
+	"maintenance.scheduled":        "Внимание! Через %s (%s) начнется техническое обслуживание, в это время игра будет недоступна",
+	"maintenance.scheduled_window": "Внимание! Через %s (%s - %s) пройдет техническое обслуживание, в это время игра будет недоступна",
+	"maintenance.active_until":     "Внимание! Идет техническое обслуживание, игра недоступна. Окончание через %s (%s)",
+	"maintenance.with_reason":      "%s. Причина: %s",
+	"countdown.days":               "%d д %d ч",
+	"countdown.hours":              "%d ч %02d мин",
+	"countdown.minutes":            "%d мин %02d с",
+	"countdown.seconds":            "%d с",
+	"maintenance.active":           "Внимание! Идет техническое обслуживание, игра недоступна",
+
+	// Новости
+	"news.title":        "📰 Новости",
+	"news.title_unread": "📰 Новости • непрочитанных: %d",
+	"news.more_above":   "↑ еще %d",
+	"news.more_below":   "↓ еще %d",
+	"news.published":    "%s, %s",
+	"news.expires":      "Актуально до %s (%s)",
+	"news.open_hint":    "Tab - читать новости",
+	"news.footer":       "↑/↓ - листать новости • Tab/Esc - к меню • Q - выход",
+	"err.news.write":    "не удалось сохранить прочитанные новости: %v",
+
+	// Кэш манифеста
+	"err.manifest_cache.missing":       "сохраненного манифеста нет",
+	"err.manifest_cache.read":          "не удалось прочитать сохраненный манифест: %v",
+	"err.manifest_cache.write":         "не удалось сохранить манифест: %v",
+	"err.manifest_cache.schema":        "сохраненный манифест записан в формате %d, нужен %d",
+	"err.manifest_cache.other_channel": "сохранен манифест другого канала: %s",
+	"err.manifest_cache.checksum":      "контрольная сумма сохраненного манифеста не совпадает",
+	"launcher.offline_cached":          "Сервер недоступен, показаны данные от %s",
+	"cli.status.manifest_cache":        "Кэш манифеста:     %s",
+	"cli.status.no_manifest_cache":     "Кэш манифеста:     нет (%s)",
+
+	// Часы
+	"clock.behind":          "Часы компьютера отстают от часов сервера на %s. Обслуживание и новости рассчитываются по времени сервера",
+	"clock.ahead":           "Часы компьютера спешат относительно часов сервера на %s. Обслуживание и новости рассчитываются по времени сервера",
+	"cli.status.clock_skew": "Часы:              %s",
+
+	// Время
+	"time.in":               "через %s",
+	"time.ago":              "%s назад",
+	"err.time.unknown_zone": "неизвестный часовой пояс: %s",
+	"err.time.no_zone":      "время %s записано без смещения, а timezone в манифесте не указан",
+	"err.time.nonexistent":  "время %s не существует в поясе %s: часы переводятся вперед",
+	"err.time.ambiguous":    "время %s в поясе %s неоднозначно: часы переводятся назад, укажите смещение",
+
+	// Обязательные и отложенные обновления
+	"update_prompt.launcher":          "Вышла новая версия лаунчера %s (у вас %s)",
+	"update_prompt.game":              "Вышла новая версия игры %s (у вас %s)",
+	"update_prompt.now":               "🔄 Обновить сейчас",
+	"update_prompt.later_days":        "⏰ Напомнить через %d дн.",
+	"update_prompt.later_next_start":  "⏰ Напомнить при следующем запуске",
+	"update_prompt.skip":              "⏭️  Пропустить версию",
+	"update_prompt.footer":            "↑/↓ - навигация • Enter - выбрать • Esc - напомнить позже",
+	"updates.remember_failed":         "Не удалось запомнить выбор: %v",
+	"err.updates.write":               "не удалось сохранить отложенные обновления: %v",
+	"launcher.update_required":        "Версия лаунчера %s больше не поддерживается, обновление до %s обязательно",
+	"launcher.update_required_manual": "Версия лаунчера %s больше не поддерживается, нужна версия не ниже %s. %s",
+	"menu.game_update_required":       "🔴 Требуется обновление игры до версии %s",
+	"cli.launcher_update_required":    "Версия лаунчера %s больше не поддерживается, нужна версия не ниже %s: выполните self-update",
+	"cli.game_update_required":        "Версия игры %s больше не поддерживается, нужна версия не ниже %s: выполните update",
+
+	// Описания версий перед обновлением
+	"release_notes.title":     "Обновление %s → %s",
+	"release_notes.size":      "загрузка %s",
+	"release_notes.version":   "Версия %s",
+	"release_notes.position":  "строки %d-%d из %d",
+	"release_notes.footer":    "↑/↓, PgUp/PgDn - прокрутка • Enter - обновить • Esc - назад",
+	"size.mb":                 "%.1f MB",
+	"size.gb":                 "%.1f GB",
+	"err.update_size_unknown": "сервер не сообщил размер архива",
+
+	// Постепенный выпуск версий
+	"err.install_id.read":  "не удалось прочитать идентификатор установки: %v",
+	"err.install_id.write": "не удалось сохранить идентификатор установки: %v",
+
+	// Заблокированные версии лаунчера
+	"launcher.blocked.force_update":         "В версии лаунчера %s найдена критическая ошибка, лаунчер нужно обновить",
+	"launcher.blocked.disable_game_updates": "В версии лаунчера %s найдена ошибка: установка и обновление игры отключены до обновления лаунчера",
+	"launcher.blocked.warn":                 "В версии лаунчера %s найдена ошибка, рекомендуем обновить лаунчер",
+	"launcher.blocked_no_update":            "%s. Исправленная версия еще не вышла, попробуйте позже",
+	"launcher.blocked_manual":               "%s. %s",
+	"cli.launcher_blocked":                  "%s. Обновите лаунчер командой self-update",
+}
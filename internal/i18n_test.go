@@ -0,0 +1,31 @@
+package internal
+
+import "testing"
+
+func TestDetectSystemLanguage(t *testing.T) {
+	tests := []struct {
+		name                    string
+		lcAll, lcMessages, lang string
+		want                    string
+	}{
+		{name: "локаль не задана", want: defaultLanguage},
+		{name: "русская локаль", lang: "ru_RU.UTF-8", want: LanguageRu},
+		{name: "английская локаль", lang: "en_US.UTF-8", want: LanguageEn},
+		{name: "другая локаль", lang: "de_DE.UTF-8", want: LanguageEn},
+		{name: "локаль C", lang: "C", want: LanguageEn},
+		{name: "локаль C.UTF-8", lang: "C.UTF-8", want: LanguageEn},
+		{name: "локаль POSIX", lang: "POSIX", want: LanguageEn},
+		{name: "LC_ALL важнее LANG", lcAll: "C", lang: "ru_RU.UTF-8", want: LanguageEn},
+		{name: "LC_MESSAGES важнее LANG", lcMessages: "ru_RU.UTF-8", lang: "C", want: LanguageRu},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.lang)
+			if got := detectSystemLanguage(); got != tt.want {
+				t.Errorf("detectSystemLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"archive/zip"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,27 +23,35 @@ const (
 	StateExtracting
 	StateCompleted
 	StateError
+	StateCancelling
+	StateCancelled
 )
 
+// StagingDirName - папка внутри директории игры, куда распаковывается новая версия
+// до замены установленных файлов
+const StagingDirName = ".launcher-staging"
+
 // InstallProgress представляет прогресс установки
 type InstallProgress struct {
-	Current int
-	Total   int
-	Message string
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+	Message string `json:"message"`
 }
 
 // InstallModel - модель TUI для процесса установки
 type InstallModel struct {
-	width        int
-	height       int
-	state        InstallState
-	progress     InstallProgress
-	errorMsg     string
-	gameDirPath  string
-	launcherPath string
-	completed    bool
-	spinner      int
-	tickCount    int
+	width         int
+	height        int
+	state         InstallState
+	progress      InstallProgress
+	errorMsg      string
+	gameDirPath   string
+	launcherPath  string
+	completed     bool
+	spinner       int
+	tickCount     int
+	cancel        context.CancelFunc // Отменяет фоновую установку
+	confirmCancel bool               // Показывается запрос подтверждения отмены
 }
 
 // Стили для установки
@@ -85,7 +96,7 @@ func NewInstallModel(gameDirPath, launcherPath string) InstallModel {
 		state:        StatePreparation,
 		gameDirPath:  gameDirPath,
 		launcherPath: launcherPath,
-		progress:     InstallProgress{Current: 0, Total: 100, Message: "Подготовка..."},
+		progress:     InstallProgress{Current: 0, Total: 100, Message: T("install.preparing")},
 	}
 }
 
@@ -93,8 +104,24 @@ func NewInstallModel(gameDirPath, launcherPath string) InstallModel {
 type InstallProgressMsg InstallProgress
 type InstallErrorMsg string
 type InstallCompleteMsg struct{}
+type InstallCancelledMsg struct{}
 type TickMsg time.Time
 
+// isOperationActive проверяет, идет ли еще фоновая операция
+func isOperationActive(state InstallState) bool {
+	return state == StatePreparation || state == StateDownloading || state == StateExtracting
+}
+
+// isConfirmKey проверяет, подтверждает ли клавиша действие (раскладки en/ru)
+func isConfirmKey(key string) bool {
+	return isYesAnswer(key)
+}
+
+// isRejectKey проверяет, отклоняет ли клавиша действие (раскладки en/ru)
+func isRejectKey(key string) bool {
+	return isNoAnswer(key) || key == "esc"
+}
+
 func (m InstallModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.startInstallation(),
@@ -112,7 +139,7 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TickMsg:
 		m.tickCount++
 		m.spinner = (m.spinner + 1) % len(spinnerFrames)
-		if m.state != StateCompleted && m.state != StateError {
+		if isOperationActive(m.state) || m.state == StateCancelling {
 			return m, m.tickCmd()
 		}
 		return m, nil
@@ -129,15 +156,42 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case InstallErrorMsg:
 		m.state = StateError
 		m.errorMsg = string(msg)
+		m.confirmCancel = false
 		return m, nil
 
 	case InstallCompleteMsg:
 		m.state = StateCompleted
 		m.completed = true
+		m.confirmCancel = false
+		return m, nil
+
+	case InstallCancelledMsg:
+		m.state = StateCancelled
+		m.confirmCancel = false
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.state == StateCompleted || m.state == StateError {
+		if isOperationActive(m.state) {
+			key := msg.String()
+			if m.confirmCancel {
+				if isConfirmKey(key) {
+					m.confirmCancel = false
+					m.state = StateCancelling
+					if m.cancel != nil {
+						m.cancel()
+					}
+				} else if isRejectKey(key) {
+					m.confirmCancel = false
+				}
+				return m, nil
+			}
+			switch key {
+			case "ctrl+c", "q", "esc":
+				m.confirmCancel = true
+			}
+			return m, nil
+		}
+		if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
 			switch msg.String() {
 			case "enter", " ":
 				return m, tea.Quit
@@ -154,33 +208,41 @@ func (m InstallModel) View() string {
 	container := containerStyle.Width(m.width).Height(m.height)
 
 	// Логотип
-	logo := `🚢 УСТАНОВКА СУБМАРИНЫ 🚢`
+	logo := T("install.logo")
 	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
 
 	// Статус в зависимости от состояния
 	switch m.state {
 	case StatePreparation:
-		statusMsg := fmt.Sprintf("%s Подготовка к установке...", spinnerFrames[m.spinner])
+		statusMsg := T("install.state_preparing", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateDownloading:
-		statusMsg := fmt.Sprintf("%s Загрузка игры...", spinnerFrames[m.spinner])
+		statusMsg := T("install.state_downloading", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateExtracting:
-		statusMsg := fmt.Sprintf("%s Распаковка файлов...", spinnerFrames[m.spinner])
+		statusMsg := T("install.state_extracting", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateCompleted:
-		content += installCompleteStyle.Width(m.width).Render("✅ Установка завершена успешно!") + "\n\n"
+		content += installCompleteStyle.Width(m.width).Render(T("install.completed")) + "\n\n"
 
 	case StateError:
-		content += installErrorStyle.Width(m.width).Render("❌ Ошибка установки") + "\n"
+		content += installErrorStyle.Width(m.width).Render(T("install.error")) + "\n"
 		content += installErrorStyle.Width(m.width).Render(m.errorMsg) + "\n\n"
+
+	case StateCancelling:
+		statusMsg := T("install.cancelling", spinnerFrames[m.spinner])
+		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
+
+	case StateCancelled:
+		content += installErrorStyle.Width(m.width).Render(T("install.cancelled")) + "\n"
+		content += installStatusStyle.Width(m.width).Render(T("install.cancelled_hint")) + "\n\n"
 	}
 
 	// Прогресс бар
-	if m.state != StateError {
+	if m.state != StateError && m.state != StateCancelled {
 		progressBar := m.renderProgressBar()
 		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(progressBar) + "\n\n"
 
@@ -188,9 +250,14 @@ func (m InstallModel) View() string {
 		content += installStatusStyle.Width(m.width).Render(m.progress.Message) + "\n\n"
 	}
 
+	// Запрос подтверждения отмены
+	if m.confirmCancel {
+		content += renderCancelConfirm(m.width, T("install.confirm_cancel")) + "\n\n"
+	}
+
 	// Инструкции
-	if m.state == StateCompleted || m.state == StateError {
-		footer := footerStyle.Width(m.width).Render("Нажмите Enter для продолжения")
+	if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
+		footer := footerStyle.Width(m.width).Render(T("ui.press_enter_to_continue"))
 		contentHeight := strings.Count(content, "\n") + 3
 		emptyLines := (m.height - contentHeight) / 2
 		if emptyLines < 0 {
@@ -214,9 +281,20 @@ func (m InstallModel) View() string {
 	}
 
 	result := strings.Repeat("\n", emptyLines) + content
+	if isOperationActive(m.state) && !m.confirmCancel {
+		result += footerStyle.Width(m.width).Render(T("ui.footer_cancel"))
+	}
 	return container.Render(result)
 }
 
+// renderCancelConfirm отрисовывает запрос подтверждения отмены операции
+func renderCancelConfirm(width int, question string) string {
+	confirmBox := fmt.Sprintf("%s\n\n%s",
+		errorStyle.Render("🛑 "+question),
+		statusStyle.Render(T("ui.cancel_confirm_hint")))
+	return lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(boxStyle.Render(confirmBox))
+}
+
 func (m InstallModel) renderProgressBar() string {
 	barWidth := 50
 	percent := float64(m.progress.Current) / float64(m.progress.Total)
@@ -246,7 +324,7 @@ func (m InstallModel) startInstallation() tea.Cmd {
 			// Пока что имитация
 			time.Sleep(time.Second * 1)
 		}()
-		return InstallProgressMsg{Current: 10, Total: 100, Message: "Создание директории..."}
+		return InstallProgressMsg{Current: 10, Total: 100, Message: T("install.creating_dir")}
 	}
 }
 
@@ -262,9 +340,17 @@ func (m InstallModel) GetError() string {
 	return m.errorMsg
 }
 
+func (m InstallModel) IsCancelled() bool {
+	return m.state == StateCancelled
+}
+
 // RunInstallationTUI запускает процесс установки в TUI режиме
-func RunInstallationTUI(gameDirPath, launcherPath string) error {
+func RunInstallationTUI(gameDirPath, launcherPath string, manifest *ManifestDto) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	model := NewInstallModel(gameDirPath, launcherPath)
+	model.cancel = cancel
 
 	// Создаем канал для обновления прогресса
 	progressChan := make(chan InstallProgress, 100)
@@ -281,20 +367,20 @@ func RunInstallationTUI(gameDirPath, launcherPath string) error {
 		defer close(completeChan)
 
 		// Создание директории
-		progressChan <- InstallProgress{Current: 5, Total: 100, Message: "Создание директории игры..."}
+		progressChan <- InstallProgress{Current: 5, Total: 100, Message: T("install.creating_game_dir")}
 		if err := createGameDirectory(gameDirPath); err != nil {
 			errorChan <- err
 			return
 		}
 
 		// Загрузка и установка
-		progressChan <- InstallProgress{Current: 10, Total: 100, Message: "Начало загрузки..."}
-		if err := installGameWithProgress(gameDirPath, launcherPath, progressChan); err != nil {
+		progressChan <- InstallProgress{Current: 10, Total: 100, Message: T("install.starting_download")}
+		if err := installGameWithProgress(ctx, gameDirPath, launcherPath, "install", manifest, progressChan); err != nil {
 			errorChan <- err
 			return
 		}
 
-		progressChan <- InstallProgress{Current: 100, Total: 100, Message: "Установка завершена!"}
+		progressChan <- InstallProgress{Current: 100, Total: 100, Message: T("install.done")}
 		completeChan <- true
 	}()
 
@@ -313,7 +399,9 @@ func RunInstallationTUI(gameDirPath, launcherPath string) error {
 					errorChan = nil
 					continue
 				}
-				if err != nil {
+				if errors.Is(err, context.Canceled) {
+					p.Send(InstallCancelledMsg{})
+				} else if err != nil {
 					p.Send(InstallErrorMsg(err.Error()))
 				}
 			case _, ok := <-completeChan:
@@ -336,6 +424,9 @@ func RunInstallationTUI(gameDirPath, launcherPath string) error {
 	}
 
 	installModel := finalModel.(InstallModel)
+	if installModel.IsCancelled() {
+		return context.Canceled
+	}
 	if installModel.HasError() {
 		return fmt.Errorf("installation failed: %s", installModel.GetError())
 	}
@@ -346,44 +437,152 @@ func RunInstallationTUI(gameDirPath, launcherPath string) error {
 // createGameDirectory создает директорию для игры
 func createGameDirectory(gameDirPath string) error {
 	if _, err := os.Stat(gameDirPath); os.IsNotExist(err) {
-		return os.Mkdir(gameDirPath, 0755)
+		if err := os.Mkdir(gameDirPath, 0755); err != nil {
+			if os.IsPermission(err) {
+				return newError("err.install.no_permission", gameDirPath)
+			}
+			return err
+		}
 	}
-	return nil
+	return CheckGameDirWritable(gameDirPath)
 }
 
-// installGameWithProgress выполняет установку игры с отправкой прогресса
-func installGameWithProgress(gameDirPath, launcherPath string, progressChan chan<- InstallProgress) error {
-	// Удаление старых файлов
-	progressChan <- InstallProgress{Current: 15, Total: 100, Message: "Очистка старых файлов..."}
-	if err := removeOldFilesQuiet(gameDirPath, launcherPath); err != nil {
-		return fmt.Errorf("ошибка при удалении старых файлов: %v", err)
+// installGameWithProgress выполняет установку игры с отправкой прогресса.
+// Архив распаковывается в промежуточную папку, и установленные файлы заменяются
+// только после успешной распаковки, поэтому отмена не затрагивает текущую игру.
+// Все этапы записываются в журнал, и прерванная распаковка продолжается с места остановки
+func installGameWithProgress(ctx context.Context, gameDirPath, launcherPath, operation string, manifest *ManifestDto, progressChan chan<- InstallProgress) (err error) {
+	stagingPath := filepath.Join(gameDirPath, StagingDirName)
+	targetVersion := ""
+	if manifest != nil {
+		targetVersion = manifest.Version.Game
 	}
 
-	// Создание временного файла
-	progressChan <- InstallProgress{Current: 20, Total: 100, Message: "Подготовка к загрузке..."}
-	archiveFile, err := os.CreateTemp("", ArchiveNameTemplate)
-	if err != nil {
-		return fmt.Errorf("ошибка при создании временного файла архива: %v", err)
+	journal, err := readInstallJournal(gameDirPath)
+	if err != nil || (journal != nil && !journal.canResumeExtraction(targetVersion)) {
+		journal = nil
+	}
+
+	var archiveFile *os.File
+	offset := int64(0)
+	if journal != nil {
+		archiveFile, err = os.Open(journal.ArchivePath)
+		if err != nil {
+			return newError("err.install.open_resumed", err)
+		}
+		LogLauncher("Продолжаем распаковку версии %s с файла %d", journal.TargetVersion, journal.ExtractedFiles)
+	} else {
+		if err := os.RemoveAll(stagingPath); err != nil {
+			return newError("err.install.clean_staging", err)
+		}
+
+		// Создание временного файла
+		progressChan <- InstallProgress{Current: 20, Total: 100, Message: T("install.preparing_download")}
+		archiveFile, offset, err = openArchiveForDownload()
+		if err != nil {
+			return newError("err.install.create_archive", err)
+		}
+
+		journal = newInstallJournal(gameDirPath, operation, targetVersion)
+		journal.ArchivePath = archiveFile.Name()
+		if err := journal.save(); err != nil {
+			archiveFile.Close()
+			return err
+		}
 	}
 
+	// До начала замены файлов установленная игра не тронута. Отмена или поврежденный архив
+	// откатывают установку целиком, а после обрыва связи или нехватки места архив,
+	// журнал и промежуточная папка остаются, чтобы следующая попытка продолжила с места остановки
+	committing := false
 	archivePath := archiveFile.Name()
 	defer func() {
 		archiveFile.Close()
+		if committing {
+			return
+		}
+		if !isInstallAbandoned(err) {
+			LogLauncher("Установка прервана на этапе %s, продолжим при следующей попытке: %v", journal.Phase, err)
+			return
+		}
+		os.RemoveAll(stagingPath)
 		os.Remove(archivePath)
+		os.Remove(archivePath + archiveMetaSuffix)
+		journal.remove()
 	}()
 
-	// Загрузка архива
-	progressChan <- InstallProgress{Current: 25, Total: 100, Message: "Загрузка архива игры..."}
-	if err := downloadZipWithProgress(archiveFile, progressChan); err != nil {
-		return fmt.Errorf("ошибка при загрузке архива: %v", err)
+	if journal.Phase == JournalDownloading {
+		// Загрузка архива
+		if offset > 0 {
+			progressChan <- InstallProgress{Current: 25, Total: 100, Message: T("install.resuming", float64(offset)/(1024*1024))}
+		} else {
+			progressChan <- InstallProgress{Current: 25, Total: 100, Message: T("install.downloading_archive")}
+		}
+		if err := downloadZipWithProgress(ctx, archiveFile, offset, progressChan); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			return newError("err.install.download", err)
+		}
+		if err := journal.setPhase(JournalExtracting); err != nil {
+			return err
+		}
 	}
 
 	// Распаковка архива
-	progressChan <- InstallProgress{Current: 70, Total: 100, Message: "Распаковка файлов игры..."}
-	if err := unzipWithProgressTUI(archivePath, gameDirPath, progressChan); err != nil {
-		return fmt.Errorf("ошибка при распаковке архива: %v", err)
+	progressChan <- InstallProgress{Current: 70, Total: 100, Message: T("install.extracting")}
+	if err := unzipWithProgressTUI(ctx, archivePath, stagingPath, journal, progressChan); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		return newError("err.install.extract", err)
+	}
+
+	// Проверяем распакованную игру до замены установленной версии
+	progressChan <- InstallProgress{Current: 95, Total: 100, Message: T("install.validating")}
+	if err := ValidateGameInstall(stagingPath, manifest); err != nil {
+		LogLauncher("Проверка версии %s не пройдена: %v", targetVersion, err)
+		return err
 	}
 
+	// После этой точки отмена не применяется: заменяем файлы целиком
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	archiveFile.Close()
+	os.Remove(archivePath)
+	os.Remove(archivePath + archiveMetaSuffix)
+
+	// Замена старых файлов новыми
+	committing = true
+	progressChan <- InstallProgress{Current: 96, Total: 100, Message: T("install.applying")}
+	return commitStagedInstall(journal, gameDirPath, launcherPath)
+}
+
+// isInstallAbandoned проверяет, что после ошибки установку не продолжить с места остановки:
+// пользователь ее отменил, архив поврежден или распакованная игра не прошла проверку
+func isInstallAbandoned(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, zip.ErrChecksum) ||
+		errors.Is(err, zip.ErrFormat) ||
+		errors.Is(err, zip.ErrAlgorithm) ||
+		errors.Is(err, ErrInvalidInstall)
+}
+
+// moveStagedFiles переносит распакованные файлы из промежуточной папки в директорию игры
+func moveStagedFiles(stagingPath, gameDirPath string) error {
+	entries, err := os.ReadDir(stagingPath)
+	if err != nil {
+		return newError("err.read_dir", stagingPath, err)
+	}
+	for _, entry := range entries {
+		src := filepath.Join(stagingPath, entry.Name())
+		dst := filepath.Join(gameDirPath, entry.Name())
+		if err := os.Rename(src, dst); err != nil {
+			return newError("err.install.move_file", dst, err)
+		}
+	}
 	return nil
 }
 
@@ -391,16 +590,16 @@ func installGameWithProgress(gameDirPath, launcherPath string, progressChan chan
 func removeOldFilesQuiet(dir, launcherPath string) error {
 	dirEntries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("ошибка при чтении директории %s: %v", dir, err)
+		return newError("err.read_dir", dir, err)
 	}
 	for _, entry := range dirEntries {
 		entryPath := filepath.Join(dir, entry.Name())
-		if entryPath == launcherPath {
+		if entryPath == launcherPath || isReservedEntry(entry.Name()) {
 			continue
 		}
 		err := os.RemoveAll(entryPath)
 		if err != nil {
-			return fmt.Errorf("ошибка при удалении файла %s: %v", entryPath, err)
+			return newError("err.remove_file", entryPath, err)
 		}
 		// Убираем консольный вывод для TUI режима
 	}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// serveTestArchive направляет загрузку архива игры на тестовый сервер,
+// а папку загрузок - во временную папку теста. Возвращает папку загрузок
+func serveTestArchive(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	saved := ArchiveURLs
+	url := server.URL + "/submarine.zip"
+	ArchiveURLs = DownloadGameURLs{Windows: url, Linux: url, DarwinArm64: url, DarwinIntel: url}
+	t.Cleanup(func() { ArchiveURLs = saved })
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("TMPDIR", dir)
+	return GetDownloadDir()
+}
+
+// runTestInstall запускает установку, отбрасывая прогресс. onProgress вызывается для каждого сообщения
+func runTestInstall(ctx context.Context, t *testing.T, gameDirPath string, onProgress func(InstallProgress)) error {
+	t.Helper()
+	progressChan := make(chan InstallProgress)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for progress := range progressChan {
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		}
+	}()
+	manifest := &ManifestDto{}
+	manifest.Version.Game = "0.1.8"
+	err := installGameWithProgress(ctx, gameDirPath, filepath.Join(gameDirPath, "launcher"), "install", manifest, progressChan)
+	close(progressChan)
+	<-drained
+	return err
+}
+
+// interruptedDownload отдает часть архива и закрывает соединение
+func interruptedDownload(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", `"archive-v1"`)
+	w.Header().Set("Content-Length", "4096")
+	w.WriteHeader(http.StatusOK)
+	w.Write(make([]byte, 1024))
+	w.(http.Flusher).Flush()
+	if r.Context().Err() == nil {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}
+}
+
+func TestInstallKeepsArchiveAfterInterruptedDownload(t *testing.T) {
+	downloadDir := serveTestArchive(t, interruptedDownload)
+	gameDir := t.TempDir()
+
+	err := runTestInstall(context.Background(), t, gameDir, nil)
+	if err == nil || isInstallAbandoned(err) {
+		t.Fatalf("installGameWithProgress() error = %v, want a resumable download error", err)
+	}
+
+	archives, _ := filepath.Glob(filepath.Join(downloadDir, ArchiveNameTemplate))
+	if len(archives) != 1 {
+		t.Fatalf("archives after interrupted download: %v, want one", archives)
+	}
+	info, err := os.Stat(archives[0])
+	if err != nil || info.Size() != 1024 {
+		t.Errorf("partial archive = %v, %v, want 1024 bytes", info, err)
+	}
+	meta, err := readArchiveMeta(archives[0])
+	if err != nil {
+		t.Fatalf("archive meta lost: %v", err)
+	}
+	if meta.ETag != `"archive-v1"` || meta.Size != 4096 {
+		t.Errorf("archive meta = %+v, want ETag and size of the server archive", meta)
+	}
+}
+
+func TestInstallRemovesArchiveAfterCancel(t *testing.T) {
+	downloadDir := serveTestArchive(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"archive-v1"`)
+		w.Header().Set("Content-Length", "4096")
+		w.WriteHeader(http.StatusOK)
+		w.Write(make([]byte, 1024))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	gameDir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := runTestInstall(ctx, t, gameDir, func(progress InstallProgress) {
+		// Отменяем, как только пошли байты архива
+		if progress.Current > 25 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("installGameWithProgress() error = %v, want context.Canceled", err)
+	}
+
+	if leftovers, _ := filepath.Glob(filepath.Join(downloadDir, ArchiveNameTemplate+"*")); len(leftovers) != 0 {
+		t.Errorf("files left after cancel: %v", leftovers)
+	}
+}
+
+func TestInstallResumesAfterExtractionError(t *testing.T) {
+	archive := map[string]string{
+		GameVersionFileName:        "version: 0.1.8\n",
+		GetExecutableForPlatform(): "game",
+		"data/level.pck":           "level",
+	}
+	downloads := 0
+	downloadDir := serveTestArchive(t, func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		writer := zip.NewWriter(w)
+		for _, name := range []string{GameVersionFileName, GetExecutableForPlatform(), "data/level.pck"} {
+			entry, err := writer.Create(name)
+			if err == nil {
+				_, err = entry.Write([]byte(archive[name]))
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		writer.Close()
+	})
+	gameDir := t.TempDir()
+	stagingPath := filepath.Join(gameDir, StagingDirName)
+	blocker := filepath.Join(stagingPath, "data")
+
+	// Перед распаковкой на месте папки последнего файла оказывается файл, и распаковка
+	// падает на нем, как при нехватке места на диске. Установка ждет следующей отправки
+	// прогресса, пока обработчик не вернется, поэтому файл появляется вовремя
+	err := runTestInstall(context.Background(), t, gameDir, func(progress InstallProgress) {
+		if progress.Message == T("install.extracting") {
+			if err := os.MkdirAll(stagingPath, 0755); err != nil {
+				t.Error(err)
+			}
+			if err := os.WriteFile(blocker, nil, 0644); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	if err == nil || isInstallAbandoned(err) {
+		t.Fatalf("installGameWithProgress() error = %v, want a resumable extraction error", err)
+	}
+
+	journal, err := readInstallJournal(gameDir)
+	if err != nil || journal == nil || journal.Phase != JournalExtracting {
+		t.Fatalf("journal after extraction error = %+v, %v, want phase %s", journal, err, JournalExtracting)
+	}
+	if _, err := os.Stat(filepath.Join(stagingPath, GameVersionFileName)); err != nil {
+		t.Errorf("staged files lost after extraction error: %v", err)
+	}
+	if protected := protectedArchivePath(gameDir); protected == "" || filepath.Dir(protected) != downloadDir {
+		t.Errorf("protectedArchivePath() = %q, want the downloaded archive", protected)
+	}
+
+	// Место освободилось: запуск лаунчера сохраняет журнал, а установка продолжает распаковку
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecoverInstall(gameDir, filepath.Join(gameDir, "launcher")); err != nil {
+		t.Fatalf("RecoverInstall() error = %v", err)
+	}
+	if !journal.canResumeExtraction("0.1.8") {
+		t.Fatal("extraction can not be resumed after RecoverInstall")
+	}
+	if err := runTestInstall(context.Background(), t, gameDir, nil); err != nil {
+		t.Fatalf("resumed installGameWithProgress() error = %v", err)
+	}
+	if downloads != 1 {
+		t.Errorf("archive downloaded %d times, want the extraction resumed from the saved archive", downloads)
+	}
+
+	for name, content := range archive {
+		data, err := os.ReadFile(filepath.Join(gameDir, filepath.FromSlash(name)))
+		if err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v, want %q", name, data, err, content)
+		}
+	}
+	if journal, _ := readInstallJournal(gameDir); journal != nil {
+		t.Errorf("journal left after install: %+v", journal)
+	}
+	if _, err := os.Stat(journal.ArchivePath); !os.IsNotExist(err) {
+		t.Errorf("archive left after install: %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"archive/zip"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstallJournalFileName - журнал незавершенной установки в директории игры
+const InstallJournalFileName = ".install-journal.yaml"
+
+// journalCheckpointEvery - через сколько распакованных файлов сохранять прогресс в журнал
+const journalCheckpointEvery = 50
+
+// JournalPhase - этап установки, записанный в журнал
+type JournalPhase string
+
+const (
+	JournalDownloading JournalPhase = "downloading"
+	JournalExtracting  JournalPhase = "extracting"
+	JournalRemoving    JournalPhase = "removing"
+	JournalMoving      JournalPhase = "moving"
+)
+
+// installJournal описывает текущую операцию установки, чтобы пережить падение или отключение питания
+type installJournal struct {
+	path           string
+	Operation      string       `yaml:"operation"`
+	TargetVersion  string       `yaml:"target_version"`
+	ArchiveURL     string       `yaml:"archive_url"`
+	ArchivePath    string       `yaml:"archive_path"`
+	Phase          JournalPhase `yaml:"phase"`
+	ExtractedFiles int          `yaml:"extracted_files"`
+	TotalFiles     int          `yaml:"total_files"`
+	StartedAt      time.Time    `yaml:"started_at"`
+	UpdatedAt      time.Time    `yaml:"updated_at"`
+}
+
+func newInstallJournal(gameDirPath, operation, targetVersion string) *installJournal {
+	return &installJournal{
+		path:          filepath.Join(gameDirPath, InstallJournalFileName),
+		Operation:     operation,
+		TargetVersion: targetVersion,
+		ArchiveURL:    GetArchiveURL(),
+		Phase:         JournalDownloading,
+		StartedAt:     time.Now().UTC(),
+	}
+}
+
+// readInstallJournal читает журнал установки. Возвращает nil без ошибки, если журнала нет
+func readInstallJournal(gameDirPath string) (*installJournal, error) {
+	path := filepath.Join(gameDirPath, InstallJournalFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var journal installJournal
+	if err := yaml.Unmarshal(data, &journal); err != nil {
+		return nil, newError("err.journal.parse", err)
+	}
+	journal.path = path
+	return &journal, nil
+}
+
+// save атомарно записывает журнал на диск
+func (j *installJournal) save() error {
+	j.UpdatedAt = time.Now().UTC()
+	data, err := yaml.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := j.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return newError("err.journal.write", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return newError("err.journal.write", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return newError("err.journal.write", err)
+	}
+	file.Close()
+	return os.Rename(tmpPath, j.path)
+}
+
+// setPhase переводит журнал на новый этап
+func (j *installJournal) setPhase(phase JournalPhase) error {
+	j.Phase = phase
+	return j.save()
+}
+
+// checkpoint запоминает количество полностью распакованных файлов
+func (j *installJournal) checkpoint(extracted int) error {
+	j.ExtractedFiles = extracted
+	return j.save()
+}
+
+func (j *installJournal) remove() {
+	os.Remove(j.path)
+	os.Remove(j.path + ".tmp")
+}
+
+// canResumeExtraction проверяет, можно ли продолжить распаковку с места остановки.
+// Распаковка другой версии не продолжается, даже если адрес архива не изменился.
+// Пустая targetVersion означает, что версия с сервера неизвестна, и сравнивается только адрес
+func (j *installJournal) canResumeExtraction(targetVersion string) bool {
+	if j.Phase != JournalExtracting || j.ArchiveURL != GetArchiveURL() || j.ArchivePath == "" {
+		return false
+	}
+	if targetVersion != "" && j.TargetVersion != targetVersion {
+		return false
+	}
+	reader, err := zip.OpenReader(j.ArchivePath)
+	if err != nil {
+		return false
+	}
+	reader.Close()
+	return true
+}
+
+// isStagedFileIntact проверяет, что файл из архива уже полностью распакован.
+// Размера недостаточно: после отключения питания файл нужной длины может оказаться
+// заполнен нулями, поэтому содержимое сверяется с CRC32 из архива
+func isStagedFileIntact(path string, file *zip.File) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if file.FileInfo().IsDir() {
+		return info.IsDir()
+	}
+	if !info.Mode().IsRegular() || uint64(info.Size()) != file.UncompressedSize64 {
+		return false
+	}
+
+	staged, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer staged.Close()
+	sum := crc32.NewIEEE()
+	if _, err := io.Copy(sum, staged); err != nil {
+		return false
+	}
+	return sum.Sum32() == file.CRC32
+}
+
+// HasIncompleteInstall проверяет, не находится ли директория игры в середине замены файлов.
+// Такую игру нельзя считать установленной, даже если version.yaml уже на месте.
+// Прерванная загрузка или распаковка установленную версию не затрагивает
+func HasIncompleteInstall(gameDirPath string) bool {
+	journal, err := readInstallJournal(gameDirPath)
+	if err != nil {
+		return true
+	}
+	return journal != nil && (journal.Phase == JournalRemoving || journal.Phase == JournalMoving)
+}
+
+// commitStagedInstall заменяет установленные файлы распакованными из промежуточной папки.
+// Этап записывается в журнал, поэтому после сбоя замену можно довести до конца
+func commitStagedInstall(journal *installJournal, gameDirPath, launcherPath string) error {
+	stagingPath := filepath.Join(gameDirPath, StagingDirName)
+
+	if journal.Phase != JournalMoving {
+		if err := journal.setPhase(JournalRemoving); err != nil {
+			return err
+		}
+		if err := removeOldFilesQuiet(gameDirPath, launcherPath); err != nil {
+			return newError("err.update.remove_old", err)
+		}
+		if err := journal.setPhase(JournalMoving); err != nil {
+			return err
+		}
+	}
+
+	if err := moveStagedFiles(stagingPath, gameDirPath); err != nil {
+		return newError("err.journal.apply", err)
+	}
+
+	// Журнал удаляется первым: пустая промежуточная папка без журнала безопасна
+	journal.remove()
+	os.RemoveAll(stagingPath)
+	return nil
+}
+
+// RecoverInstall разбирает журнал прерванной установки при запуске лаунчера.
+// Прерванная замена файлов доводится до конца, прерванная загрузка откатывается,
+// а прерванная распаковка остается в журнале и продолжится при следующей установке
+func RecoverInstall(gameDirPath, launcherPath string) error {
+	journal, err := readInstallJournal(gameDirPath)
+	if err != nil {
+		LogLauncher("Восстановление: журнал установки поврежден, откатываем: %v", err)
+		journal = &installJournal{path: filepath.Join(gameDirPath, InstallJournalFileName)}
+	}
+	if journal == nil {
+		return nil
+	}
+
+	stagingPath := filepath.Join(gameDirPath, StagingDirName)
+
+	switch journal.Phase {
+	case JournalRemoving, JournalMoving:
+		if _, err := os.Stat(stagingPath); err != nil {
+			// Новых файлов не осталось, а старые уже частично удалены: игру нужно ставить заново
+			LogLauncher("Восстановление: промежуточная папка потеряна, удаляем неполную установку %s", journal.TargetVersion)
+			if err := removeOldFilesQuiet(gameDirPath, launcherPath); err != nil {
+				return err
+			}
+			journal.remove()
+			ShowStyledMessage(Warn, T("journal.unrecoverable"))
+			return nil
+		}
+
+		LogLauncher("Восстановление: завершаем замену файлов версии %s (этап %s)", journal.TargetVersion, journal.Phase)
+		if err := commitStagedInstall(journal, gameDirPath, launcherPath); err != nil {
+			return err
+		}
+		ShowStyledMessage(Success, T("journal.recovered"))
+		return nil
+
+	case JournalExtracting:
+		// Версия с сервера здесь неизвестна, ее сверит установка перед продолжением
+		if journal.canResumeExtraction("") {
+			LogLauncher("Восстановление: распаковка версии %s остановилась на %d/%d файлах, продолжим при установке",
+				journal.TargetVersion, journal.ExtractedFiles, journal.TotalFiles)
+			ShowStyledMessage(Warn, T("journal.resume"))
+			return nil
+		}
+	}
+
+	// Загрузка не завершена или архив потерян: установленная игра не тронута, откатываемся
+	LogLauncher("Восстановление: откатываем прерванную операцию %s (этап %s)", journal.Operation, journal.Phase)
+	if err := os.RemoveAll(stagingPath); err != nil {
+		return newError("err.journal.remove_staging", err)
+	}
+	if journal.Phase == JournalExtracting && journal.ArchivePath != "" {
+		os.Remove(journal.ArchivePath)
+		os.Remove(journal.ArchivePath + archiveMetaSuffix)
+	}
+	journal.remove()
+	return nil
+}
+
+// protectedArchivePath возвращает путь к архиву, который еще нужен журналу установки
+func protectedArchivePath(gameDirPath string) string {
+	journal, err := readInstallJournal(gameDirPath)
+	if err != nil || journal == nil || journal.Phase != JournalExtracting {
+		return ""
+	}
+	return journal.ArchivePath
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestArchive создает zip-архив с одним файлом и возвращает путь к нему
+func writeTestArchive(t *testing.T, dir, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, "archive.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	entry, err := writer.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entry.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCanResumeExtraction(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := writeTestArchive(t, dir, "game.bin", []byte("game"))
+	brokenPath := filepath.Join(dir, "broken.zip")
+	if err := os.WriteFile(brokenPath, []byte("not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		journal       installJournal
+		targetVersion string
+		want          bool
+	}{
+		{
+			name:          "та же версия и архив",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: GetArchiveURL(), ArchivePath: archivePath, TargetVersion: "0.1.8"},
+			targetVersion: "0.1.8",
+			want:          true,
+		},
+		{
+			name:          "версия с сервера неизвестна",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: GetArchiveURL(), ArchivePath: archivePath, TargetVersion: "0.1.8"},
+			targetVersion: "",
+			want:          true,
+		},
+		{
+			name:          "на сервере другая версия",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: GetArchiveURL(), ArchivePath: archivePath, TargetVersion: "0.1.8"},
+			targetVersion: "0.1.9",
+			want:          false,
+		},
+		{
+			name:          "другой адрес архива",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: "https://example.com/other.zip", ArchivePath: archivePath, TargetVersion: "0.1.8"},
+			targetVersion: "0.1.8",
+			want:          false,
+		},
+		{
+			name:          "загрузка не завершена",
+			journal:       installJournal{Phase: JournalDownloading, ArchiveURL: GetArchiveURL(), ArchivePath: archivePath, TargetVersion: "0.1.8"},
+			targetVersion: "0.1.8",
+			want:          false,
+		},
+		{
+			name:          "архив потерян",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: GetArchiveURL(), ArchivePath: filepath.Join(dir, "missing.zip"), TargetVersion: "0.1.8"},
+			targetVersion: "0.1.8",
+			want:          false,
+		},
+		{
+			name:          "архив поврежден",
+			journal:       installJournal{Phase: JournalExtracting, ArchiveURL: GetArchiveURL(), ArchivePath: brokenPath, TargetVersion: "0.1.8"},
+			targetVersion: "0.1.8",
+			want:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.journal.canResumeExtraction(tt.targetVersion); got != tt.want {
+				t.Errorf("canResumeExtraction(%q) = %v, want %v", tt.targetVersion, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStagedFileIntact(t *testing.T) {
+	content := []byte("submarine game data")
+	dir := t.TempDir()
+	reader, err := zip.OpenReader(writeTestArchive(t, dir, "game.bin", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	entry := reader.File[0]
+
+	tests := []struct {
+		name   string
+		staged []byte // nil - файла нет
+		want   bool
+	}{
+		{name: "файл распакован целиком", staged: content, want: true},
+		{name: "файла нет", staged: nil, want: false},
+		{name: "файл короче", staged: content[:5], want: false},
+		{name: "нули той же длины", staged: make([]byte, len(content)), want: false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "staged", string(rune('a'+i)))
+			if tt.staged != nil {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, tt.staged, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := isStagedFileIntact(path, entry); got != tt.want {
+				t.Errorf("isStagedFileIntact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
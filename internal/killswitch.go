@@ -0,0 +1,102 @@
+package internal
+
+// Что делать с заблокированной версией лаунчера
+const (
+	BlockActionForceUpdate        = "force_update"         // Обновиться без вопросов, иначе не работать
+	BlockActionDisableGameUpdates = "disable_game_updates" // Не устанавливать и не обновлять игру
+	BlockActionWarn               = "warn"                 // Только предупредить
+)
+
+// BlockedLauncher - версия или диапазон версий лаунчера с критической ошибкой.
+// Версия задается полем version или границами min и max (включительно, любую можно опустить)
+type BlockedLauncher struct {
+	Version string        `yaml:"version,omitempty" json:"version,omitempty"`
+	Min     string        `yaml:"min,omitempty" json:"min,omitempty"`
+	Max     string        `yaml:"max,omitempty" json:"max,omitempty"`
+	Action  string        `yaml:"action" json:"action"`
+	Message LocalizedText `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Matches проверяет, попадает ли версия в запись. Запись без версии и границ не блокирует
+// ничего, чтобы опечатка в манифесте не остановила все лаунчеры
+func (b BlockedLauncher) Matches(version string) bool {
+	if b.Version == "" && b.Min == "" && b.Max == "" {
+		return false
+	}
+	if b.Version != "" && !versionEquals(version, b.Version) {
+		return false
+	}
+	if b.Min != "" {
+		if result, err := CompareVersions(version, b.Min); err != nil || result < 0 {
+			return false
+		}
+	}
+	if b.Max != "" {
+		if result, err := CompareVersions(version, b.Max); err != nil || result > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// versionEquals сравнивает версии семантически, а несравнимые - как строки
+func versionEquals(a, b string) bool {
+	result, err := CompareVersions(a, b)
+	if err != nil {
+		return a == b
+	}
+	return result == 0
+}
+
+// severity упорядочивает действия по строгости. Неизвестное действие считается предупреждением
+func (b BlockedLauncher) severity() int {
+	switch b.Action {
+	case BlockActionForceUpdate:
+		return 2
+	case BlockActionDisableGameUpdates:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Text возвращает сообщение из манифеста или стандартный текст для действия
+func (b BlockedLauncher) Text() string {
+	if text := b.Message.String(); text != "" {
+		return text
+	}
+	switch b.Action {
+	case BlockActionForceUpdate:
+		return T("launcher.blocked.force_update", LauncherVersion)
+	case BlockActionDisableGameUpdates:
+		return T("launcher.blocked.disable_game_updates", LauncherVersion)
+	default:
+		return T("launcher.blocked.warn", LauncherVersion)
+	}
+}
+
+// LauncherBlockFor возвращает самую строгую запись манифеста, под которую попадает
+// запущенная версия лаунчера, или nil
+func LauncherBlockFor(manifest *ManifestDto) *BlockedLauncher {
+	if manifest == nil {
+		return nil
+	}
+	var block *BlockedLauncher
+	for i := range manifest.BlockedLaunchers {
+		entry := &manifest.BlockedLaunchers[i]
+		if !entry.Matches(LauncherVersion) {
+			continue
+		}
+		if block == nil || entry.severity() > block.severity() {
+			block = entry
+		}
+	}
+	return block
+}
+
+// GameUpdatesBlocked сообщает, что этой версии лаунчера запрещено устанавливать и обновлять игру.
+// Версия, которая должна обновиться, но не смогла, тоже не трогает файлы игры
+func GameUpdatesBlocked(manifest *ManifestDto) bool {
+	block := LauncherBlockFor(manifest)
+	return block != nil && (block.Action == BlockActionDisableGameUpdates || block.Action == BlockActionForceUpdate)
+}
@@ -0,0 +1,89 @@
+package internal
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		v1, v2  string
+		want    int
+		wantErr bool
+	}{
+		{v1: "0.0.13", v2: "0.0.13", want: 0},
+		{v1: "0.0.13", v2: "0.0.14", want: -1},
+		{v1: "0.1.0", v2: "0.0.99", want: 1},
+		{v1: "1.2", v2: "1.2.0", want: 0},
+		{v1: "0.1.8-alpha", v2: "0.1.8-beta", want: -1},
+		{v1: "0.1.8-beta", v2: "0.1.8", want: -1},
+		{v1: "0.1.8", v2: "0.1.8-alpha", want: 1},
+		{v1: "0.1.8-rc", v2: "0.1.8-alpha", want: -1},
+		{v1: "0.1.9-alpha", v2: "0.1.8", want: 1},
+		{v1: "dev", v2: "0.1.8", wantErr: true},
+		{v1: "0.1.8", v2: "1.2.3.4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.v1+" vs "+tt.v2, func(t *testing.T) {
+			got, err := CompareVersions(tt.v1, tt.v2)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CompareVersions(%q, %q) = %d, want error", tt.v1, tt.v2, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompareVersions(%q, %q) error: %v", tt.v1, tt.v2, err)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockedLauncherMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   BlockedLauncher
+		version string
+		want    bool
+	}{
+		{name: "пустая запись", entry: BlockedLauncher{}, version: "0.0.13", want: false},
+		{name: "точная версия", entry: BlockedLauncher{Version: "0.0.13"}, version: "0.0.13", want: true},
+		{name: "версия в неверном формате", entry: BlockedLauncher{Version: "0.0.13"}, version: "0.0.13.0", want: false},
+		{name: "другая версия", entry: BlockedLauncher{Version: "0.0.13"}, version: "0.0.14", want: false},
+		{name: "несравнимая версия по строке", entry: BlockedLauncher{Version: "dev"}, version: "dev", want: true},
+		{name: "нижняя граница включительно", entry: BlockedLauncher{Min: "0.0.10"}, version: "0.0.10", want: true},
+		{name: "ниже нижней границы", entry: BlockedLauncher{Min: "0.0.10"}, version: "0.0.9", want: false},
+		{name: "верхняя граница включительно", entry: BlockedLauncher{Max: "0.0.12"}, version: "0.0.12", want: true},
+		{name: "выше верхней границы", entry: BlockedLauncher{Max: "0.0.12"}, version: "0.0.13", want: false},
+		{name: "внутри диапазона", entry: BlockedLauncher{Min: "0.0.10", Max: "0.0.12"}, version: "0.0.11", want: true},
+		{name: "суффикс ниже релиза", entry: BlockedLauncher{Min: "0.0.10", Max: "0.0.12"}, version: "0.0.10-beta", want: false},
+		{name: "несравнимая версия вне диапазона", entry: BlockedLauncher{Min: "0.0.10"}, version: "dev", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.Matches(tt.version); got != tt.want {
+				t.Errorf("%+v.Matches(%q) = %v, want %v", tt.entry, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLauncherBlockForPicksMostSevere(t *testing.T) {
+	manifest := &ManifestDto{BlockedLaunchers: []BlockedLauncher{
+		{Version: LauncherVersion, Action: BlockActionWarn},
+		{Max: LauncherVersion, Action: BlockActionForceUpdate},
+		{Min: LauncherVersion, Action: BlockActionDisableGameUpdates},
+	}}
+	block := LauncherBlockFor(manifest)
+	if block == nil || block.Action != BlockActionForceUpdate {
+		t.Fatalf("LauncherBlockFor() = %+v, want %s", block, BlockActionForceUpdate)
+	}
+	if !GameUpdatesBlocked(manifest) {
+		t.Error("GameUpdatesBlocked() = false, want true")
+	}
+	if LauncherBlockFor(&ManifestDto{}) != nil {
+		t.Error("LauncherBlockFor() without entries should be nil")
+	}
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,8 +14,12 @@ import (
 
 // updateLauncherWithProgress выполняет обновление лаунчера с отчетом о прогрессе
 func updateLauncherWithProgress(currentLauncherPath string, progressChan chan<- InstallProgress) error {
+	if err := CheckLauncherDirWritable(currentLauncherPath); err != nil {
+		return errors.New(ManualUpdateHint(currentLauncherPath))
+	}
+
 	// Отправляем начальный прогресс
-	progressChan <- InstallProgress{Current: 5, Total: 100, Message: "Подготовка к загрузке..."}
+	progressChan <- InstallProgress{Current: 5, Total: 100, Message: T("self_update.preparing_download")}
 
 	// Определяем пути
 	dir := filepath.Dir(currentLauncherPath)
@@ -26,7 +31,7 @@ func updateLauncherWithProgress(currentLauncherPath string, progressChan chan<-
 	tempLauncherPath := filepath.Join(dir, "SubmarineLauncher_new"+ext)
 	oldLauncherPath := filepath.Join(dir, "SubmarineLauncher_old"+ext)
 
-	progressChan <- InstallProgress{Current: 10, Total: 100, Message: "Начинаем загрузку новой версии..."}
+	progressChan <- InstallProgress{Current: 10, Total: 100, Message: T("self_update.downloading")}
 
 	// Загружаем новую версию лаунчера
 	err := downloadLauncherUpdateWithProgress(tempLauncherPath, progressChan)
@@ -34,41 +39,16 @@ func updateLauncherWithProgress(currentLauncherPath string, progressChan chan<-
 		return err
 	}
 
-	progressChan <- InstallProgress{Current: 80, Total: 100, Message: "Создание скрипта обновления..."}
+	progressChan <- InstallProgress{Current: 80, Total: 100, Message: T("self_update.creating_script")}
 
 	// Создаем и запускаем скрипт обновления
-	var scriptPath string
-	var scriptContent string
-
-	if runtime.GOOS == "windows" {
-		scriptPath = filepath.Join(dir, "update_launcher.bat")
-		scriptContent = fmt.Sprintf(`@echo off
-timeout /t 2 /nobreak >nul
-move "%s" "%s"
-move "%s" "%s"
-del "%s"
-start "" "%s"
-del "%%~f0"
-`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, oldLauncherPath, currentLauncherPath)
-	} else {
-		scriptPath = filepath.Join(dir, "update_launcher.sh")
-		scriptContent = fmt.Sprintf(`#!/bin/bash
-sleep 2
-mv "%s" "%s"
-mv "%s" "%s"
-rm "%s"
-"%s" &
-rm "$0"
-`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, oldLauncherPath, currentLauncherPath)
-	}
-
-	err = os.WriteFile(scriptPath, []byte(scriptContent), 0755)
+	scriptPath, err := writeLauncherUpdateScript(currentLauncherPath, tempLauncherPath, oldLauncherPath, true)
 	if err != nil {
 		os.Remove(tempLauncherPath)
-		return fmt.Errorf("ошибка при создании скрипта: %v", err)
+		return newError("err.self_update.create_script", err)
 	}
 
-	progressChan <- InstallProgress{Current: 90, Total: 100, Message: "Запуск скрипта обновления..."}
+	progressChan <- InstallProgress{Current: 90, Total: 100, Message: T("self_update.running_script")}
 
 	// Запускаем скрипт обновления
 	var cmd *exec.Cmd
@@ -82,10 +62,10 @@ rm "$0"
 	if err != nil {
 		os.Remove(tempLauncherPath)
 		os.Remove(scriptPath)
-		return fmt.Errorf("ошибка при запуске скрипта: %v", err)
+		return newError("err.self_update.run_script", err)
 	}
 
-	progressChan <- InstallProgress{Current: 100, Total: 100, Message: "Обновление завершено!"}
+	progressChan <- InstallProgress{Current: 100, Total: 100, Message: T("self_update.done")}
 
 	// Небольшая задержка перед завершением процесса
 	time.Sleep(2 * time.Second)
@@ -95,23 +75,23 @@ rm "$0"
 
 // downloadLauncherUpdateWithProgress загружает обновление лаунчера с прогрессом
 func downloadLauncherUpdateWithProgress(tempPath string, progressChan chan<- InstallProgress) error {
-	progressChan <- InstallProgress{Current: 15, Total: 100, Message: "Подключение к серверу..."}
+	progressChan <- InstallProgress{Current: 15, Total: 100, Message: T("self_update.connecting")}
 
 	resp, err := http.Get(GetLauncherURL())
 	if err != nil {
-		return fmt.Errorf("ошибка при загрузке обновления: %v", err)
+		return newError("err.self_update.download", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("неожиданный статус ответа %d при загрузке обновления", resp.StatusCode)
+		return newError("err.self_update.status", resp.StatusCode)
 	}
 
-	progressChan <- InstallProgress{Current: 20, Total: 100, Message: "Начинаем загрузку файла..."}
+	progressChan <- InstallProgress{Current: 20, Total: 100, Message: T("self_update.starting_file")}
 
 	out, err := os.Create(tempPath)
 	if err != nil {
-		return fmt.Errorf("ошибка при создании файла: %v", err)
+		return newError("err.create_file", err)
 	}
 	defer out.Close()
 
@@ -129,7 +109,7 @@ func downloadLauncherUpdateWithProgress(tempPath string, progressChan chan<- Ins
 		if n > 0 {
 			_, writeErr := out.Write(buffer[:n])
 			if writeErr != nil {
-				return fmt.Errorf("ошибка при записи файла: %v", writeErr)
+				return newError("err.write_file", writeErr)
 			}
 			written += int64(n)
 
@@ -142,52 +122,59 @@ func downloadLauncherUpdateWithProgress(tempPath string, progressChan chan<- Ins
 			progressChan <- InstallProgress{
 				Current: current,
 				Total:   100,
-				Message: fmt.Sprintf("Загружено: %.1f MB", float64(written)/1024/1024),
+				Message: T("self_update.downloaded_mb", float64(written)/1024/1024),
 			}
 		}
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("ошибка при чтении файла: %v", err)
+			return newError("err.read_file", err)
 		}
 	}
 
-	progressChan <- InstallProgress{Current: 75, Total: 100, Message: "Загрузка завершена!"}
+	progressChan <- InstallProgress{Current: 75, Total: 100, Message: T("self_update.download_done")}
 	return nil
 }
 
 // DownloadLauncherUpdate загружает обновление лаунчера (старая функция для совместимости)
 func DownloadLauncherUpdate(tempPath string) error {
-	ShowStyledMessage(Info, "Загрузка обновления...")
+	ShowStyledMessage(Info, T("self_update.downloading_update"))
 
 	resp, err := http.Get(GetLauncherURL())
 	if err != nil {
-		return fmt.Errorf("ошибка при загрузке обновления: %v", err)
+		return newError("err.self_update.download", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("неожиданный статус ответа %d при загрузке обновления", resp.StatusCode)
+		return newError("err.self_update.status", resp.StatusCode)
 	}
 
 	out, err := os.Create(tempPath)
 	if err != nil {
-		return fmt.Errorf("ошибка при создании файла: %v", err)
+		return newError("err.create_file", err)
 	}
 	defer out.Close()
 
 	_, err = io.Copy(out, resp.Body)
 	if err != nil {
-		return fmt.Errorf("ошибка при записи файла: %v", err)
+		return newError("err.write_file", err)
 	}
 
-	ShowStyledMessage(Success, "Обновление скачано успешно!")
+	ShowStyledMessage(Success, T("self_update.downloaded"))
 	return nil
 }
 
-// UpdateLauncher выполняет самообновление лаунчера (старая функция для совместимости)
-func UpdateLauncher(currentLauncherPath string) error {
+// UpdateLauncher выполняет самообновление лаунчера без TUI.
+// Если restart выключен, новая версия не запускается после замены файла.
+// Файл заменяет скрипт после завершения лаунчера, поэтому при успехе вызывающий
+// должен сразу завершить процесс
+func UpdateLauncher(currentLauncherPath string, restart bool) error {
+	if err := CheckLauncherDirWritable(currentLauncherPath); err != nil {
+		return errors.New(ManualUpdateHint(currentLauncherPath))
+	}
+
 	// Определяем пути для файлов
 	dir := filepath.Dir(currentLauncherPath)
 	ext := ""
@@ -205,38 +192,13 @@ func UpdateLauncher(currentLauncherPath string) error {
 	}
 
 	// Создаем и записываем скрипт для замены файлов
-	var scriptPath string
-	var scriptContent string
-
-	if runtime.GOOS == "windows" {
-		scriptPath = filepath.Join(dir, "update_launcher.bat")
-		scriptContent = fmt.Sprintf(`@echo off
-timeout /t 2 /nobreak >nul
-move "%s" "%s"
-move "%s" "%s"
-del "%s"
-start "" "%s"
-del "%%~f0"
-`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, oldLauncherPath, currentLauncherPath)
-	} else {
-		scriptPath = filepath.Join(dir, "update_launcher.sh")
-		scriptContent = fmt.Sprintf(`#!/bin/bash
-sleep 2
-mv "%s" "%s"
-mv "%s" "%s"
-rm "%s"
-"%s" &
-rm "$0"
-`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, oldLauncherPath, currentLauncherPath)
-	}
-
-	err = os.WriteFile(scriptPath, []byte(scriptContent), 0755)
+	scriptPath, err := writeLauncherUpdateScript(currentLauncherPath, tempLauncherPath, oldLauncherPath, restart)
 	if err != nil {
 		os.Remove(tempLauncherPath) // Очищаем файл
-		return fmt.Errorf("ошибка при создании скрипта: %v", err)
+		return newError("err.self_update.create_script", err)
 	}
 
-	ShowStyledMessage(Info, "Выполняем обновление...")
+	ShowStyledMessage(Info, T("self_update.applying"))
 
 	// Запускаем скрипт и завершаем процесс
 	var cmd *exec.Cmd
@@ -250,10 +212,56 @@ rm "$0"
 	if err != nil {
 		os.Remove(tempLauncherPath)
 		os.Remove(scriptPath)
-		return fmt.Errorf("ошибка запуска скрипта: %v", err)
+		return newError("err.self_update.run_script", err)
 	}
 
-	ShowStyledMessage(Success, "Обновление запущено! Лаунчер перезапустится...")
-	os.Exit(0) // Завершаем текущий процесс
+	if restart {
+		ShowStyledMessage(Success, T("self_update.started_restart"))
+	} else {
+		ShowStyledMessage(Success, T("self_update.started_no_restart"))
+	}
 	return nil
 }
+
+// writeLauncherUpdateScript создает скрипт, который после завершения лаунчера
+// подменяет его файл новой версией и при необходимости запускает ее
+func writeLauncherUpdateScript(currentLauncherPath, tempLauncherPath, oldLauncherPath string, restart bool) (string, error) {
+	dir := filepath.Dir(currentLauncherPath)
+
+	var scriptPath string
+	var scriptContent string
+
+	if runtime.GOOS == "windows" {
+		startLine := ""
+		if restart {
+			startLine = fmt.Sprintf("start \"\" \"%s\"\n", currentLauncherPath)
+		}
+		scriptPath = filepath.Join(dir, "update_launcher.bat")
+		scriptContent = fmt.Sprintf(`@echo off
+timeout /t 2 /nobreak >nul
+move "%s" "%s"
+move "%s" "%s"
+del "%s"
+%sdel "%%~f0"
+`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, oldLauncherPath, startLine)
+	} else {
+		startLine := ""
+		if restart {
+			startLine = fmt.Sprintf("\"%s\" &\n", currentLauncherPath)
+		}
+		scriptPath = filepath.Join(dir, "update_launcher.sh")
+		scriptContent = fmt.Sprintf(`#!/bin/bash
+sleep 2
+mv "%s" "%s"
+mv "%s" "%s"
+chmod +x "%s"
+rm "%s"
+%srm "$0"
+`, currentLauncherPath, oldLauncherPath, tempLauncherPath, currentLauncherPath, currentLauncherPath, oldLauncherPath, startLine)
+	}
+
+	if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
+		return "", err
+	}
+	return scriptPath, nil
+}
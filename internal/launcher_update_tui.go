@@ -29,7 +29,7 @@ func NewLauncherUpdateModel(launcherPath string) LauncherUpdateModel {
 		height:       24,
 		state:        StatePreparation,
 		launcherPath: launcherPath,
-		progress:     InstallProgress{Current: 0, Total: 100, Message: "Подготовка к обновлению лаунчера..."},
+		progress:     InstallProgress{Current: 0, Total: 100, Message: T("self_update.preparing")},
 	}
 }
 
@@ -94,29 +94,29 @@ func (m LauncherUpdateModel) View() string {
 	container := containerStyle.Width(m.width).Height(m.height)
 
 	// Логотип
-	logo := `🚀 ОБНОВЛЕНИЕ ЛАУНЧЕРА 🚀`
+	logo := T("self_update.logo")
 	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
 
 	// Статус в зависимости от состояния
 	switch m.state {
 	case StatePreparation:
-		statusMsg := fmt.Sprintf("%s Подготовка к обновлению лаунчера...", spinnerFrames[m.spinner])
+		statusMsg := T("self_update.state_preparing", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateDownloading:
-		statusMsg := fmt.Sprintf("%s Загрузка новой версии лаунчера...", spinnerFrames[m.spinner])
+		statusMsg := T("self_update.state_downloading", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateExtracting:
-		statusMsg := fmt.Sprintf("%s Установка обновления лаунчера...", spinnerFrames[m.spinner])
+		statusMsg := T("self_update.state_installing", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateCompleted:
-		content += installCompleteStyle.Width(m.width).Render("✅ Обновление лаунчера завершено!") + "\n\n"
-		content += installStatusStyle.Width(m.width).Render("Лаунчер будет перезапущен автоматически...") + "\n\n"
+		content += installCompleteStyle.Width(m.width).Render(T("self_update.completed")) + "\n\n"
+		content += installStatusStyle.Width(m.width).Render(T("self_update.restart")) + "\n\n"
 
 	case StateError:
-		content += installErrorStyle.Width(m.width).Render("❌ Ошибка обновления лаунчера") + "\n"
+		content += installErrorStyle.Width(m.width).Render(T("self_update.error")) + "\n"
 		content += installErrorStyle.Width(m.width).Render(m.errorMsg) + "\n\n"
 	}
 
@@ -131,7 +131,7 @@ func (m LauncherUpdateModel) View() string {
 
 	// Инструкции
 	if m.state == StateCompleted || m.state == StateError {
-		footer := footerStyle.Width(m.width).Render("Нажмите Enter для продолжения")
+		footer := footerStyle.Width(m.width).Render(T("ui.press_enter_to_continue"))
 		contentHeight := strings.Count(content, "\n") + 3
 		emptyLines := (m.height - contentHeight) / 2
 		if emptyLines < 0 {
@@ -181,7 +181,7 @@ func (m LauncherUpdateModel) tickCmd() tea.Cmd {
 
 func (m LauncherUpdateModel) startLauncherUpdate() tea.Cmd {
 	return func() tea.Msg {
-		return InstallProgressMsg{Current: 0, Total: 100, Message: "Инициализация обновления..."}
+		return InstallProgressMsg{Current: 0, Total: 100, Message: T("self_update.initializing")}
 	}
 }
 
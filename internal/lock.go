@@ -0,0 +1,283 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName - файл блокировки директории игры
+const LockFileName = ".launcher.lock"
+
+// lockTakeoverSuffix - файл рядом с блокировкой, который на время удаления устаревшей
+// блокировки захватывает только один лаунчер
+const lockTakeoverSuffix = ".takeover"
+
+const (
+	lockAttempts   = 50                     // Сколько раз пробовать создать блокировку
+	lockRetryDelay = 100 * time.Millisecond // Пауза между попытками
+	// lockWriteGrace - сколько ждать, пока другой лаунчер допишет только что созданную блокировку
+	lockWriteGrace = 2 * time.Second
+	// lockTakeoverTimeout - после этого срока файл замены считается оставшимся от упавшего лаунчера
+	lockTakeoverTimeout = 10 * time.Second
+	// processStartTolerance - запас при сравнении времени запуска процесса с временем из блокировки:
+	// время запуска округляется, а процесс записывает блокировку уже после запуска
+	processStartTolerance = 5 * time.Second
+)
+
+// ErrInstanceLocked возвращается, если директорией игры уже управляет другой лаунчер
+var ErrInstanceLocked = newError("err.lock.locked")
+
+// LockInfo описывает содержимое файла блокировки
+type LockInfo struct {
+	PID           int       `yaml:"pid"`
+	StartedAt     time.Time `yaml:"started_at"`
+	GamePID       int       `yaml:"game_pid,omitempty"`
+	GameStartedAt time.Time `yaml:"game_started_at,omitempty"`
+}
+
+// isHolderAlive проверяет, что лаунчер, создавший блокировку, еще работает
+func (info *LockInfo) isHolderAlive() bool {
+	return isSameProcess(info.PID, info.StartedAt)
+}
+
+// isGameAlive проверяет, что игра, запущенная под блокировкой, еще работает
+func (info *LockInfo) isGameAlive() bool {
+	return info.GamePID != 0 && isSameProcess(info.GamePID, info.GameStartedAt)
+}
+
+// isSameProcess проверяет, что процесс с указанным PID жив и запущен не позже startedAt.
+// Процесс, запущенный позже, получил PID после завершения прежнего владельца, например
+// после перезагрузки. Если время запуска узнать не удалось, достаточно того, что процесс жив
+func isSameProcess(pid int, startedAt time.Time) bool {
+	if !isProcessAlive(pid) {
+		return false
+	}
+	if startedAt.IsZero() {
+		return true
+	}
+	start, ok := processStartTime(pid)
+	if !ok {
+		return true
+	}
+	return !start.After(startedAt.Add(processStartTolerance))
+}
+
+// InstanceLock - рекомендательная блокировка директории игры
+type InstanceLock struct {
+	mu   sync.Mutex
+	path string
+	info LockInfo
+}
+
+// reservedEntries - служебные файлы лаунчера, которые не удаляются вместе со старыми файлами игры.
+// В портативном режиме папка настроек совпадает с папкой лаунчера, а она может быть и папкой игры,
+// поэтому сюда входят и файлы из папки настроек
+var reservedEntries = map[string]bool{
+	StagingDirName:                    true,
+	LockFileName:                      true,
+	InstallJournalFileName:            true,
+	LockFileName + lockTakeoverSuffix: true,
+	PortableMarkerFileName:            true,
+	SettingsFileName:                  true,
+	InstallIDFileName:                 true,
+	NewsFileName:                      true,
+	UpdatesFileName:                   true,
+	ManifestCacheFileName:             true,
+}
+
+// isReservedEntry проверяет, является ли файл в директории игры служебным файлом лаунчера.
+// Временные файлы атомарной записи (.tmp) тоже считаются служебными
+func isReservedEntry(name string) bool {
+	return reservedEntries[name] || reservedEntries[strings.TrimSuffix(name, ".tmp")]
+}
+
+// AcquireInstanceLock захватывает блокировку директории игры.
+// Если блокировку держит живой процесс, возвращает информацию о нем и ErrInstanceLocked
+func AcquireInstanceLock(gameDirPath string) (*InstanceLock, *LockInfo, error) {
+	if err := os.MkdirAll(gameDirPath, 0755); err != nil {
+		return nil, nil, newError("err.lock.create_dir", err)
+	}
+
+	lockPath := filepath.Join(gameDirPath, LockFileName)
+	info := LockInfo{PID: os.Getpid(), StartedAt: time.Now().UTC()}
+
+	for attempt := 0; attempt < lockAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(lockRetryDelay)
+		}
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			lock := &InstanceLock{path: lockPath, info: info}
+			err = writeLockInfo(file, lock.info)
+			file.Close()
+			if err != nil {
+				os.Remove(lockPath)
+				return nil, nil, newError("err.lock.write", err)
+			}
+			return lock, nil, nil
+		}
+		if !os.IsExist(err) {
+			return nil, nil, newError("err.lock.create", err)
+		}
+
+		holder, err := ReadLockInfo(gameDirPath)
+		if err == nil && holder.PID != info.PID && holder.isHolderAlive() {
+			return nil, holder, ErrInstanceLocked
+		}
+		if err != nil && isLockBeingWritten(lockPath) {
+			continue
+		}
+
+		// Блокировка устарела: владелец завершился, но игра могла остаться запущенной
+		if err == nil && holder.isGameAlive() {
+			info.GamePID = holder.GamePID
+			info.GameStartedAt = holder.GameStartedAt
+		}
+		if err := removeStaleLock(gameDirPath, info.PID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return nil, nil, newError("err.lock.acquire", lockPath)
+}
+
+// isLockBeingWritten проверяет, не создана ли нечитаемая блокировка только что: между созданием
+// файла и записью в него другой лаунчер еще не успел сохранить свой PID
+func isLockBeingWritten(lockPath string) bool {
+	info, err := os.Stat(lockPath)
+	return err == nil && time.Since(info.ModTime()) < lockWriteGrace
+}
+
+// removeStaleLock удаляет устаревшую блокировку. Удаляет ее только лаунчер, захвативший файл
+// замены, и только после повторной проверки: иначе два лаунчера, одновременно нашедшие
+// устаревшую блокировку, могли бы удалить новую блокировку друг друга
+func removeStaleLock(gameDirPath string, pid int) error {
+	lockPath := filepath.Join(gameDirPath, LockFileName)
+	takeoverPath := lockPath + lockTakeoverSuffix
+
+	takeover, err := os.OpenFile(takeoverPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if !os.IsExist(err) {
+			return newError("err.lock.remove_stale", err)
+		}
+		// Блокировку уже заменяет другой лаунчер, если только он не упал посередине
+		if info, err := os.Stat(takeoverPath); err == nil && time.Since(info.ModTime()) > lockTakeoverTimeout {
+			os.Remove(takeoverPath)
+		}
+		return nil
+	}
+	takeover.Close()
+	defer os.Remove(takeoverPath)
+
+	holder, err := ReadLockInfo(gameDirPath)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err == nil && holder.PID != pid && holder.isHolderAlive():
+		// Пока проверяли, блокировку захватил другой лаунчер
+		return nil
+	case err != nil && isLockBeingWritten(lockPath):
+		return nil
+	}
+	if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+		return newError("err.lock.remove_stale", err)
+	}
+	return nil
+}
+
+// ReadLockInfo читает информацию о текущем владельце блокировки
+func ReadLockInfo(gameDirPath string) (*LockInfo, error) {
+	data, err := os.ReadFile(filepath.Join(gameDirPath, LockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var info LockInfo
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return nil, newError("err.lock.parse", err)
+	}
+	return &info, nil
+}
+
+func writeLockInfo(file *os.File, info LockInfo) error {
+	data, err := yaml.Marshal(info)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+// SetGamePID запоминает PID запущенной игры (0 - игра завершена)
+func (l *InstanceLock) SetGamePID(pid int) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.info.GamePID = pid
+	l.info.GameStartedAt = time.Time{}
+	if pid != 0 {
+		l.info.GameStartedAt = time.Now().UTC()
+	}
+	data, err := yaml.Marshal(l.info)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		ShowStyledMessage(Warn, T("lock.update_failed", err))
+	}
+}
+
+// IsGameRunning проверяет, работает ли еще игра, запущенная под этой блокировкой
+func (l *InstanceLock) IsGameRunning() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.info.isGameAlive()
+}
+
+// Release снимает блокировку, если она все еще принадлежит этому процессу
+func (l *InstanceLock) Release() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return
+	}
+	var current LockInfo
+	if err := yaml.Unmarshal(data, &current); err != nil || current.PID != l.info.PID {
+		return
+	}
+	os.Remove(l.path)
+}
+
+// SwitchInstanceLock захватывает блокировку новой директории игры и только затем освобождает старую.
+// При ошибке старая блокировка остается в силе
+func SwitchInstanceLock(lock *InstanceLock, gameDirPath string) (*InstanceLock, error) {
+	newLock, holder, err := AcquireInstanceLock(gameDirPath)
+	if errors.Is(err, ErrInstanceLocked) {
+		return lock, errors.New(describeLockHolder(holder))
+	}
+	if err != nil {
+		return lock, err
+	}
+	lock.Release()
+	// Старая директория удаляется, только если в ней ничего не осталось
+	if lock != nil {
+		os.Remove(filepath.Dir(lock.path))
+	}
+	return newLock, nil
+}
@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LockChoice - решение пользователя, когда директория игры занята другим лаунчером
+type LockChoice int
+
+const (
+	LockChoiceQuit LockChoice = iota
+	LockChoiceAcquired
+	LockChoiceReadOnly
+)
+
+// InstanceLockModel - модель TUI для ожидания освобождения директории игры
+type InstanceLockModel struct {
+	width       int
+	height      int
+	gameDirPath string
+	holder      *LockInfo
+	choices     []string
+	cursor      int
+	waiting     bool
+	spinner     int
+	tickCount   int
+	result      LockChoice
+	lock        *InstanceLock
+	errorMsg    string
+}
+
+// NewInstanceLockModel создает модель выбора действия при занятой директории игры
+func NewInstanceLockModel(gameDirPath string, holder *LockInfo) InstanceLockModel {
+	return InstanceLockModel{
+		width:       80,
+		height:      24,
+		gameDirPath: gameDirPath,
+		holder:      holder,
+		choices:     []string{T("lock.wait"), T("lock.read_only"), T("menu.exit")},
+		result:      LockChoiceQuit,
+	}
+}
+
+func (m InstanceLockModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m InstanceLockModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case TickMsg:
+		if !m.waiting {
+			return m, nil
+		}
+		m.tickCount++
+		m.spinner = (m.spinner + 1) % len(spinnerFrames)
+
+		// Пробуем захватить блокировку примерно раз в секунду
+		if m.tickCount%10 == 0 {
+			lock, holder, err := AcquireInstanceLock(m.gameDirPath)
+			if err == nil {
+				m.lock = lock
+				m.result = LockChoiceAcquired
+				return m, tea.Quit
+			}
+			if !errors.Is(err, ErrInstanceLocked) {
+				m.waiting = false
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			m.holder = holder
+		}
+		return m, m.tickCmd()
+
+	case tea.KeyMsg:
+		if m.waiting {
+			switch msg.String() {
+			case "ctrl+c", "q", "esc":
+				m.waiting = false
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.result = LockChoiceQuit
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.choices)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			switch m.cursor {
+			case 0:
+				m.waiting = true
+				m.errorMsg = ""
+				return m, m.tickCmd()
+			case 1:
+				m.result = LockChoiceReadOnly
+				return m, tea.Quit
+			default:
+				m.result = LockChoiceQuit
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m InstanceLockModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("menu.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+
+	warning := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD43B")).
+		Bold(true).
+		Render("⚠️  " + describeLockHolder(m.holder))
+	statusBox := boxStyle.Width(m.width - 10).Render(warning)
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(statusBox) + "\n\n"
+
+	if m.errorMsg != "" {
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(errorStyle.Render("❌ "+m.errorMsg)) + "\n\n"
+	}
+
+	var footer string
+	if m.waiting {
+		statusMsg := T("lock.waiting", spinnerFrames[m.spinner])
+		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
+		footer = footerStyle.Width(m.width).Render(T("lock.footer_waiting"))
+	} else {
+		menu := ""
+		for i, choice := range m.choices {
+			if m.cursor == i {
+				menu += selectedItemStyle.Width(30).Align(lipgloss.Center).Render("▶ "+choice) + "\n"
+			} else {
+				menu += menuItemStyle.Width(30).Align(lipgloss.Center).Render("  "+choice) + "\n"
+			}
+		}
+		menuContainer := boxStyle.Width(40).Render(menu)
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(menuContainer)
+		footer = footerStyle.Width(m.width).Render(T("menu.footer"))
+	}
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+func (m InstanceLockModel) tickCmd() tea.Cmd {
+	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+// describeLockHolder формирует описание владельца блокировки для пользователя
+func describeLockHolder(holder *LockInfo) string {
+	if holder == nil {
+		return T("lock.holder")
+	}
+	return T("lock.holder_details",
+		holder.PID, holder.StartedAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// RunInstanceLockTUI предлагает подождать другой лаунчер, открыть статус только для чтения или выйти
+func RunInstanceLockTUI(gameDirPath string, holder *LockInfo) (*InstanceLock, LockChoice, error) {
+	model := NewInstanceLockModel(gameDirPath, holder)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, LockChoiceQuit, err
+	}
+
+	lockModel := finalModel.(InstanceLockModel)
+	return lockModel.lock, lockModel.result, nil
+}
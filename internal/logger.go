@@ -3,6 +3,18 @@
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LauncherLogFileName - журнал действий самого лаунчера
+const LauncherLogFileName = "launcher.log"
+
+var (
+	launcherLogMu   sync.Mutex
+	launcherLogFile *os.File
 )
 
 type Logger struct {
@@ -39,3 +51,66 @@ func (l *Logger) FlushRepeat() {
 		}
 	}
 }
+
+// OpenLauncherLog открывает журнал лаунчера в папке логов (запись в конец файла)
+func OpenLauncherLog(logDir string) error {
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(logDir, LauncherLogFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	launcherLogMu.Lock()
+	defer launcherLogMu.Unlock()
+	if launcherLogFile != nil {
+		launcherLogFile.Close()
+	}
+	launcherLogFile = file
+	fmt.Fprintf(file, "=== Лаунчер %s запущен: %s (PID %d) ===\n", LauncherVersion, time.Now().Format("2006-01-02 15:04:05"), os.Getpid())
+	return nil
+}
+
+// CloseLauncherLog закрывает журнал лаунчера
+func CloseLauncherLog() {
+	launcherLogMu.Lock()
+	defer launcherLogMu.Unlock()
+	if launcherLogFile != nil {
+		launcherLogFile.Close()
+		launcherLogFile = nil
+	}
+}
+
+// LogLauncher записывает строку в журнал лаунчера, если он открыт
+func LogLauncher(format string, args ...interface{}) {
+	launcherLogMu.Lock()
+	defer launcherLogMu.Unlock()
+	if launcherLogFile == nil {
+		return
+	}
+	fmt.Fprintf(launcherLogFile, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// pruneGameLogs удаляет логи игры старше указанного числа дней. При 0 логи хранятся всегда
+func pruneGameLogs(logDir string, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(filepath.Join(logDir, "game_*.log"))
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			LogLauncher("Не удалось удалить старый лог %s: %v", path, err)
+			continue
+		}
+		LogLauncher("Удален старый лог игры: %s", path)
+	}
+}
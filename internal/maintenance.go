@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow - окно технического обслуживания. Без End обслуживание длится,
+// пока окно не уберут из манифеста
+type MaintenanceWindow struct {
+	Start  CustomTime    `yaml:"start" json:"start"`
+	End    *CustomTime   `yaml:"end,omitempty" json:"end,omitempty"`
+	Reason LocalizedText `yaml:"reason,omitempty" json:"reason,omitempty"`
+	// legacy - окно из поля shutdown ранних манифестов, к нему относится shutdown_message
+	legacy bool
+}
+
+// isActive проверяет, идет ли обслуживание в момент now
+func (w MaintenanceWindow) isActive(now time.Time) bool {
+	if now.Before(w.Start.Time) {
+		return false
+	}
+	return w.End == nil || now.Before(w.End.Time)
+}
+
+// validMaintenanceWindows отбрасывает окна без начала. Пустое время начала раньше любого
+// момента, и такое окно без окончания закрыло бы игру для всех, пока его не уберут из манифеста
+func validMaintenanceWindows(windows []MaintenanceWindow) []MaintenanceWindow {
+	valid := windows[:0]
+	for _, window := range windows {
+		if window.Start.IsZero() {
+			LogLauncher("Окно обслуживания без начала пропущено: %s", window.Reason.String())
+			continue
+		}
+		valid = append(valid, window)
+	}
+	return valid
+}
+
+// maintenanceWindows возвращает окна обслуживания из манифеста, включая поле shutdown
+// ранних манифестов. Окна, которые заканчиваются раньше начала, пропускаются
+func maintenanceWindows(manifest *ManifestDto) []MaintenanceWindow {
+	if manifest == nil {
+		return nil
+	}
+	var windows []MaintenanceWindow
+	if manifest.Shutdown != nil {
+		windows = append(windows, MaintenanceWindow{Start: *manifest.Shutdown, legacy: true})
+	}
+	for _, window := range manifest.Maintenance {
+		if window.End != nil && !window.End.After(window.Start.Time) {
+			continue
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// CurrentMaintenance возвращает окно обслуживания, которое идет в момент now.
+// Если окна пересекаются, берется то, которое закончится позже
+func CurrentMaintenance(manifest *ManifestDto, now time.Time) *MaintenanceWindow {
+	var current *MaintenanceWindow
+	for _, window := range maintenanceWindows(manifest) {
+		if !window.isActive(now) {
+			continue
+		}
+		if current == nil || current.End != nil && (window.End == nil || window.End.After(current.End.Time)) {
+			window := window
+			current = &window
+		}
+	}
+	return current
+}
+
+// NextMaintenance возвращает ближайшее запланированное окно обслуживания
+func NextMaintenance(manifest *ManifestDto, now time.Time) *MaintenanceWindow {
+	var next *MaintenanceWindow
+	for _, window := range maintenanceWindows(manifest) {
+		if !window.Start.After(now) {
+			continue
+		}
+		if next == nil || window.Start.Before(next.Start.Time) {
+			window := window
+			next = &window
+		}
+	}
+	return next
+}
+
+// HasPendingMaintenance проверяет, есть ли идущее или запланированное обслуживание,
+// для которого нужен обратный отсчет
+func HasPendingMaintenance(manifest *ManifestDto, now time.Time) bool {
+	return CurrentMaintenance(manifest, now) != nil || NextMaintenance(manifest, now) != nil
+}
+
+// maintenanceText собирает сообщение об окне обслуживания с обратным отсчетом до начала
+// или до окончания. Свой текст из shutdown_message заменяет стандартный только для поля
+// shutdown ранних манифестов, для которого он и задавался: окна maintenance сохраняют отсчет
+func maintenanceText(manifest *ManifestDto, window *MaintenanceWindow, now time.Time) string {
+	// Время показывается в часовом поясе игрока
+	start := formatLocalTime(window.Start.Time)
+	end := ""
+	if window.End != nil {
+		end = formatLocalTime(window.End.Time)
+	}
+
+	var text string
+	if custom := manifest.ShutdownMessage.String(); custom != "" && window.legacy {
+		text = strings.NewReplacer("{time}", start, "{end}", end).Replace(custom)
+	} else if window.isActive(now) {
+		if window.End == nil {
+			text = T("maintenance.active")
+		} else {
+			text = T("maintenance.active_until", formatCountdown(window.End.Sub(now)), end)
+		}
+	} else {
+		countdown := formatCountdown(window.Start.Sub(now))
+		if window.End == nil {
+			text = T("maintenance.scheduled", countdown, start)
+		} else {
+			text = T("maintenance.scheduled_window", countdown, start, end)
+		}
+	}
+
+	if reason := window.Reason.String(); reason != "" {
+		text = T("maintenance.with_reason", text, reason)
+	}
+	return text
+}
+
+// formatCountdown записывает оставшееся время коротко: дни и часы, часы и минуты
+// или минуты и секунды
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	// Округляем вверх, чтобы отсчет не показывал 0 с, пока время еще не наступило
+	seconds := int((d + time.Second - 1) / time.Second)
+	switch {
+	case seconds >= 24*3600:
+		return T("countdown.days", seconds/(24*3600), seconds%(24*3600)/3600)
+	case seconds >= 3600:
+		return T("countdown.hours", seconds/3600, seconds%3600/60)
+	case seconds >= 60:
+		return T("countdown.minutes", seconds/60, seconds%60)
+	default:
+		return T("countdown.seconds", seconds)
+	}
+}
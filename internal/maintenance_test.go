@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var maintenanceTestNow = time.Date(2025, 7, 4, 12, 0, 0, 0, time.UTC)
+
+// testWindow создает окно обслуживания со смещениями в часах от maintenanceTestNow.
+// hasEnd = false - окно без окончания
+func testWindow(startHours, endHours float64, hasEnd bool, reason string) MaintenanceWindow {
+	at := func(hours float64) CustomTime {
+		return CustomTime{Time: maintenanceTestNow.Add(time.Duration(hours * float64(time.Hour)))}
+	}
+	window := MaintenanceWindow{Start: at(startHours)}
+	if hasEnd {
+		end := at(endHours)
+		window.End = &end
+	}
+	if reason != "" {
+		window.Reason = LocalizedText{neutralLanguage: reason}
+	}
+	return window
+}
+
+func TestCurrentMaintenance(t *testing.T) {
+	tests := []struct {
+		name       string
+		windows    []MaintenanceWindow
+		shutdown   float64 // Смещение поля shutdown в часах, 0 - поля нет
+		wantReason string  // Причина выбранного окна, "-" - окна нет
+		wantEnd    bool
+	}{
+		{name: "окон нет", wantReason: "-"},
+		{name: "окно закончилось", windows: []MaintenanceWindow{testWindow(-3, -1, true, "a")}, wantReason: "-"},
+		{name: "окно не началось", windows: []MaintenanceWindow{testWindow(1, 3, true, "a")}, wantReason: "-"},
+		{name: "идет одно окно", windows: []MaintenanceWindow{testWindow(-1, 1, true, "a")}, wantReason: "a", wantEnd: true},
+		{name: "окончание не входит в окно", windows: []MaintenanceWindow{testWindow(-1, 0, true, "a")}, wantReason: "-"},
+		{
+			name:       "пересечение: выбирается позднее окончание",
+			windows:    []MaintenanceWindow{testWindow(-2, 1, true, "a"), testWindow(-1, 3, true, "b"), testWindow(-3, 2, true, "c")},
+			wantReason: "b",
+			wantEnd:    true,
+		},
+		{
+			name:       "пересечение с окном без окончания",
+			windows:    []MaintenanceWindow{testWindow(-2, 5, true, "a"), testWindow(-1, 0, false, "b")},
+			wantReason: "b",
+		},
+		{
+			name:       "окно без окончания не вытесняется",
+			windows:    []MaintenanceWindow{testWindow(-1, 0, false, "a"), testWindow(-2, 5, true, "b")},
+			wantReason: "a",
+		},
+		{
+			name:       "окно с окончанием раньше начала пропускается",
+			windows:    []MaintenanceWindow{testWindow(-1, -2, true, "a")},
+			wantReason: "-",
+		},
+		{name: "поле shutdown ранних манифестов", shutdown: -1, wantReason: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &ManifestDto{Maintenance: tt.windows}
+			if tt.shutdown != 0 {
+				shutdown := CustomTime{Time: maintenanceTestNow.Add(time.Duration(tt.shutdown * float64(time.Hour)))}
+				manifest.Shutdown = &shutdown
+			}
+			got := CurrentMaintenance(manifest, maintenanceTestNow)
+			if tt.wantReason == "-" {
+				if got != nil {
+					t.Fatalf("CurrentMaintenance() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("CurrentMaintenance() = nil, want window %q", tt.wantReason)
+			}
+			if reason := got.Reason.String(); reason != tt.wantReason {
+				t.Errorf("CurrentMaintenance() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if (got.End != nil) != tt.wantEnd {
+				t.Errorf("CurrentMaintenance() has end = %v, want %v", got.End != nil, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestNextMaintenance(t *testing.T) {
+	tests := []struct {
+		name       string
+		windows    []MaintenanceWindow
+		wantReason string // "-" - окна нет
+	}{
+		{name: "окон нет", wantReason: "-"},
+		{name: "только идущее окно", windows: []MaintenanceWindow{testWindow(-1, 1, true, "a")}, wantReason: "-"},
+		{name: "одно будущее окно", windows: []MaintenanceWindow{testWindow(2, 3, true, "a")}, wantReason: "a"},
+		{
+			name:       "выбирается ближайшее",
+			windows:    []MaintenanceWindow{testWindow(5, 6, true, "a"), testWindow(1, 8, true, "b"), testWindow(3, 0, false, "c")},
+			wantReason: "b",
+		},
+		{
+			name:       "будущее окно во время идущего",
+			windows:    []MaintenanceWindow{testWindow(-1, 4, true, "a"), testWindow(2, 3, true, "b")},
+			wantReason: "b",
+		},
+		{name: "начало ровно сейчас уже идет", windows: []MaintenanceWindow{testWindow(0, 1, true, "a")}, wantReason: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextMaintenance(&ManifestDto{Maintenance: tt.windows}, maintenanceTestNow)
+			if tt.wantReason == "-" {
+				if got != nil {
+					t.Fatalf("NextMaintenance() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("NextMaintenance() = nil, want window %q", tt.wantReason)
+			}
+			if reason := got.Reason.String(); reason != tt.wantReason {
+				t.Errorf("NextMaintenance() reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMaintenanceTextCustomMessage(t *testing.T) {
+	custom := LocalizedText{neutralLanguage: "Сервер уходит на обслуживание в {time}"}
+	window := testWindow(2, 3, true, "")
+	manifest := &ManifestDto{ShutdownMessage: custom, Maintenance: []MaintenanceWindow{window}}
+
+	// Окно из maintenance показывает стандартный текст с отсчетом, а не shutdown_message
+	if text := maintenanceText(manifest, &window, maintenanceTestNow); text == custom.String() || !strings.Contains(text, formatCountdown(2*time.Hour)) {
+		t.Errorf("maintenance window text = %q, want the standard text with a countdown", text)
+	}
+
+	shutdown := CustomTime{Time: maintenanceTestNow.Add(2 * time.Hour)}
+	manifest = &ManifestDto{ShutdownMessage: custom, Shutdown: &shutdown}
+	legacy := NextMaintenance(manifest, maintenanceTestNow)
+	if legacy == nil {
+		t.Fatal("NextMaintenance() = nil for the shutdown field")
+	}
+	want := "Сервер уходит на обслуживание в " + formatLocalTime(shutdown.Time)
+	if text := maintenanceText(manifest, legacy, maintenanceTestNow); text != want {
+		t.Errorf("shutdown text = %q, want %q", text, want)
+	}
+}
+
+func TestParseManifestSkipsMaintenanceWithoutStart(t *testing.T) {
+	manifest, err := parseManifest([]byte(`
+version:
+  game: 0.1.8
+  launcher: 0.0.13
+maintenance:
+  - reason: без начала
+  - start: "2025-07-04T10:00:00Z"
+    reason: закончилось
+    end: "2025-07-04T11:00:00Z"
+  - end: "2025-07-04T14:00:00Z"
+    reason: только окончание
+  - start: "2025-07-04T11:00:00Z"
+    end: "2025-07-04T13:00:00Z"
+    reason: идет
+`))
+	if err != nil {
+		t.Fatalf("parseManifest() error = %v", err)
+	}
+	if len(manifest.Maintenance) != 2 {
+		t.Fatalf("parseManifest() kept %d maintenance windows, want 2: %+v", len(manifest.Maintenance), manifest.Maintenance)
+	}
+	if current := CurrentMaintenance(manifest, maintenanceTestNow); current == nil || current.Reason.String() != "идет" {
+		t.Errorf("CurrentMaintenance() = %+v, want the window with a start", current)
+	}
+	if CurrentMaintenance(manifest, maintenanceTestNow.Add(3*time.Hour)) != nil {
+		t.Error("a window without a start blocks the game after the other windows ended")
+	}
+}
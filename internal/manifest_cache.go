@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestCacheFileName - файл с последним полученным манифестом
+	ManifestCacheFileName = "manifest-cache.yaml"
+	// manifestCacheSchema - версия формата кэша. Увеличивается при изменении ManifestDto
+	// или полей кэша, чтобы лаунчер не читал копию, записанную по старым правилам
+	manifestCacheSchema = 1
+)
+
+// manifestCacheFile - содержимое файла кэша манифеста
+type manifestCacheFile struct {
+	Schema       int       `yaml:"schema"`
+	URL          string    `yaml:"url"`
+	ETag         string    `yaml:"etag,omitempty"`
+	LastModified string    `yaml:"last_modified,omitempty"`
+	FetchedAt    time.Time `yaml:"fetched_at"`
+	SHA256       string    `yaml:"sha256"` // Контрольная сумма body: поврежденная копия не используется
+	Body         string    `yaml:"body"`
+}
+
+// ManifestCacheInfo описывает сохраненную копию манифеста для диагностики
+type ManifestCacheInfo struct {
+	Path         string    `json:"path"`
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// getManifestCachePath возвращает путь к файлу кэша манифеста
+func getManifestCachePath() (string, error) {
+	cacheDir, err := getCacheDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, ManifestCacheFileName), nil
+}
+
+// manifestChecksum возвращает SHA-256 тела манифеста
+func manifestChecksum(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// readManifestCache читает кэш манифеста для адреса url. Копия другого канала, другой версии
+// формата или с неверной контрольной суммой считается недействительной
+func readManifestCache(url string) (*manifestCacheFile, *ManifestDto, error) {
+	path, err := getManifestCachePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, newError("err.manifest_cache.missing")
+		}
+		return nil, nil, newError("err.manifest_cache.read", err)
+	}
+
+	var cache manifestCacheFile
+	if err := yaml.Unmarshal(data, &cache); err != nil {
+		return nil, nil, newError("err.manifest_cache.read", err)
+	}
+	switch {
+	case cache.Schema != manifestCacheSchema:
+		return nil, nil, newError("err.manifest_cache.schema", cache.Schema, manifestCacheSchema)
+	case cache.URL != url:
+		return nil, nil, newError("err.manifest_cache.other_channel", cache.URL)
+	case !strings.EqualFold(cache.SHA256, manifestChecksum(cache.Body)):
+		return nil, nil, newError("err.manifest_cache.checksum")
+	}
+
+	manifest, err := parseManifest([]byte(cache.Body))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cache, manifest, nil
+}
+
+// writeManifestCache атомарно записывает кэш манифеста
+func writeManifestCache(cache *manifestCacheFile) error {
+	path, err := getManifestCachePath()
+	if err != nil {
+		return err
+	}
+	cache.Schema = manifestCacheSchema
+	cache.SHA256 = manifestChecksum(cache.Body)
+	data, err := yaml.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return newError("err.manifest_cache.write", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return newError("err.manifest_cache.write", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return newError("err.manifest_cache.write", err)
+	}
+	return nil
+}
+
+// LoadCachedManifest возвращает сохраненный манифест текущего канала и время его получения.
+// Используется, когда сервер недоступен
+func LoadCachedManifest() (*ManifestDto, time.Time, error) {
+	cache, manifest, err := readManifestCache(RemoteManifestURL)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return manifest.withRollouts(), cache.FetchedAt, nil
+}
+
+// GetManifestCacheInfo описывает сохраненную копию манифеста текущего канала
+func GetManifestCacheInfo() (*ManifestCacheInfo, error) {
+	cache, _, err := readManifestCache(RemoteManifestURL)
+	if err != nil {
+		return nil, err
+	}
+	path, _ := getManifestCachePath()
+	return &ManifestCacheInfo{
+		Path:         path,
+		URL:          cache.URL,
+		ETag:         cache.ETag,
+		LastModified: cache.LastModified,
+		FetchedAt:    cache.FetchedAt,
+	}, nil
+}
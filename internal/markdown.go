@@ -0,0 +1,373 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Стили элементов Markdown поверх основного стиля текста
+var (
+	markdownHeadingColor = lipgloss.Color("#00D4AA")
+	markdownCodeColor    = lipgloss.Color("#FFA94D")
+	markdownLinkColor    = lipgloss.Color("#4DABF7")
+	markdownMutedColor   = lipgloss.Color("#868E96")
+)
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownList    = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	markdownRule    = regexp.MustCompile(`^\s{0,3}([-*_])(\s*([-*_]))*\s*$`)
+	markdownQuote   = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	markdownFence   = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+)
+
+// mdWord - слово текста со своим стилем. space означает пробел перед словом
+type mdWord struct {
+	text  string
+	style lipgloss.Style
+	space bool
+}
+
+// markdownRenderer раскладывает Markdown по строкам заданной ширины
+type markdownRenderer struct {
+	width int
+	base  lipgloss.Style
+	lines []string
+}
+
+// renderMarkdown выводит небольшое подмножество Markdown стилями lipgloss: заголовки,
+// выделение, списки, цитаты, код и ссылки. Текст переносится по словам на ширину width,
+// при width <= 0 строки не переносятся. base задает цвет и начертание обычного текста
+func renderMarkdown(source string, width int, base lipgloss.Style) string {
+	r := &markdownRenderer{width: width, base: base}
+	r.render(strings.ReplaceAll(source, "\r\n", "\n"))
+	return strings.Join(r.lines, "\n")
+}
+
+// render разбирает текст по блокам: абзацы, заголовки, элементы списка, цитаты и код
+func (r *markdownRenderer) render(source string) {
+	var paragraph []string
+	var item *struct {
+		prefix string
+		indent string
+		text   []string
+	}
+	inCode := false
+	pendingGap := false
+
+	// flush выводит накопленный абзац или элемент списка
+	flush := func() {
+		if item != nil {
+			r.gap(&pendingGap)
+			r.wrap(r.inline(strings.Join(item.text, " "), r.base), item.prefix, item.indent)
+			item = nil
+		}
+		if len(paragraph) > 0 {
+			r.gap(&pendingGap)
+			r.wrap(r.inline(strings.Join(paragraph, " "), r.base), "", "")
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(source, "\n") {
+		if markdownFence.MatchString(line) {
+			flush()
+			if !inCode {
+				r.gap(&pendingGap)
+			} else {
+				pendingGap = true
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			r.lines = append(r.lines, "  "+r.base.Foreground(markdownCodeColor).Bold(false).Render(strings.TrimRight(line, " \t")))
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+			if len(r.lines) > 0 {
+				pendingGap = true
+			}
+
+		case markdownHeading.MatchString(trimmed):
+			flush()
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			style := r.base.Foreground(markdownHeadingColor).Bold(true)
+			if len(match[1]) == 1 {
+				style = style.Underline(true)
+			}
+			r.gap(&pendingGap)
+			r.wrap(r.inline(match[2], style), "", "")
+			pendingGap = true
+
+		case markdownRule.MatchString(line) && strings.Count(trimmed, string(trimmed[0])) >= 3:
+			flush()
+			r.gap(&pendingGap)
+			ruleWidth := r.width
+			if ruleWidth <= 0 || ruleWidth > 40 {
+				ruleWidth = 40
+			}
+			r.lines = append(r.lines, r.muted().Render(strings.Repeat("─", ruleWidth)))
+			pendingGap = true
+
+		case markdownList.MatchString(line):
+			flush()
+			match := markdownList.FindStringSubmatch(line)
+			level := len(strings.ReplaceAll(match[1], "\t", "  ")) / 2
+			marker := "•"
+			if unicode.IsDigit(rune(match[2][0])) {
+				marker = strings.TrimRight(match[2], ".)") + "."
+			} else if level > 0 {
+				marker = "◦"
+			}
+			indent := strings.Repeat("  ", level)
+			item = &struct {
+				prefix string
+				indent string
+				text   []string
+			}{
+				prefix: indent + marker + " ",
+				indent: indent + strings.Repeat(" ", lipgloss.Width(marker)+1),
+				text:   []string{match[3]},
+			}
+
+		case markdownQuote.MatchString(line):
+			flush()
+			text := markdownQuote.FindStringSubmatch(line)[1]
+			bar := r.muted().Render("│ ")
+			r.gap(&pendingGap)
+			r.wrap(r.inline(text, r.base.Italic(true)), bar, bar)
+
+		case item != nil && (line[0] == ' ' || line[0] == '\t'):
+			// Продолжение элемента списка с отступом
+			item.text = append(item.text, trimmed)
+
+		default:
+			if item != nil {
+				flush()
+				pendingGap = true
+			}
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+}
+
+// muted возвращает приглушенный стиль для служебных элементов: линий, рамки цитат, адресов ссылок
+func (r *markdownRenderer) muted() lipgloss.Style {
+	return r.base.Foreground(markdownMutedColor).Bold(false).Italic(false)
+}
+
+// gap добавляет пустую строку между блоками
+func (r *markdownRenderer) gap(pending *bool) {
+	if *pending && len(r.lines) > 0 {
+		r.lines = append(r.lines, "")
+	}
+	*pending = false
+}
+
+// inline разбирает выделение внутри строки: **жирный**, *курсив*, ~~зачеркнутый~~,
+// `код` и [ссылки](адрес). Незакрытые маркеры выводятся как есть
+func (r *markdownRenderer) inline(text string, style lipgloss.Style) []mdWord {
+	var words []mdWord
+	var current strings.Builder
+	currentStyle := style
+	space := false
+
+	emit := func() {
+		if current.Len() == 0 {
+			return
+		}
+		words = append(words, mdWord{text: current.String(), style: currentStyle, space: space})
+		current.Reset()
+		space = false
+	}
+	// add добавляет текст в одном стиле, разбивая его на слова
+	add := func(s string, wordStyle lipgloss.Style) {
+		if !sameStyle(currentStyle, wordStyle) {
+			emit()
+			currentStyle = wordStyle
+		}
+		for _, ch := range s {
+			if ch == ' ' || ch == '\t' {
+				emit()
+				space = len(words) > 0
+				continue
+			}
+			current.WriteRune(ch)
+		}
+	}
+
+	bold, italic, strike := false, false, false
+	styled := func() lipgloss.Style {
+		s := style
+		if bold {
+			s = s.Bold(true)
+		}
+		if italic {
+			s = s.Italic(true)
+		}
+		if strike {
+			s = s.Strikethrough(true)
+		}
+		return s
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		rest := string(runes[i:])
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\\`*_[]()#+-.!~>", runes[i+1]):
+			add(string(runes[i+1]), styled())
+			i++
+
+		case runes[i] == '`':
+			end := strings.IndexRune(string(runes[i+1:]), '`')
+			if end == -1 {
+				add("`", styled())
+				continue
+			}
+			code := []rune(string(runes[i+1:])[:end])
+			add(string(code), style.Foreground(markdownCodeColor).Bold(false))
+			i += len(code) + 1
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			marker := rest[:2]
+			if !bold && !strings.Contains(rest[2:], marker) {
+				add(marker, styled())
+			} else {
+				bold = !bold
+			}
+			i++
+
+		case strings.HasPrefix(rest, "~~"):
+			if !strike && !strings.Contains(rest[2:], "~~") {
+				add("~~", styled())
+			} else {
+				strike = !strike
+			}
+			i++
+
+		case runes[i] == '*' || (runes[i] == '_' && isWordBoundary(runes, i)):
+			marker := string(runes[i])
+			if !italic && (!strings.Contains(rest[1:], marker) || i+1 >= len(runes) || runes[i+1] == ' ') {
+				add(marker, styled())
+			} else {
+				italic = !italic
+			}
+
+		case runes[i] == '[':
+			label, url, length, ok := parseMarkdownLink(runes[i:])
+			if !ok {
+				add("[", styled())
+				continue
+			}
+			add(label, styled().Foreground(markdownLinkColor).Underline(true))
+			if url != label {
+				add(" ("+url+")", r.muted())
+			}
+			i += length - 1
+
+		default:
+			add(string(runes[i]), styled())
+		}
+	}
+	emit()
+	return words
+}
+
+// sameStyle сравнивает стили по начертанию, которое влияет на вывод слова
+func sameStyle(a, b lipgloss.Style) bool {
+	return a.GetBold() == b.GetBold() && a.GetItalic() == b.GetItalic() &&
+		a.GetStrikethrough() == b.GetStrikethrough() && a.GetUnderline() == b.GetUnderline() &&
+		a.GetForeground() == b.GetForeground()
+}
+
+// isWordBoundary проверяет, что символ _ стоит на границе слова, а не внутри snake_case
+func isWordBoundary(runes []rune, i int) bool {
+	before := i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1])
+	after := i+1 >= len(runes) || !unicode.IsLetter(runes[i+1]) && !unicode.IsDigit(runes[i+1])
+	return before || after
+}
+
+// parseMarkdownLink разбирает ссылку [текст](адрес) и возвращает ее длину в символах
+func parseMarkdownLink(runes []rune) (label, url string, length int, ok bool) {
+	text := string(runes)
+	closeLabel := strings.Index(text, "](")
+	if closeLabel == -1 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexRune(text[closeLabel+2:], ')')
+	if closeURL == -1 {
+		return "", "", 0, false
+	}
+	label = text[1:closeLabel]
+	url = strings.TrimSpace(text[closeLabel+2 : closeLabel+2+closeURL])
+	if label == "" {
+		label = url
+	}
+	return label, url, len([]rune(text[:closeLabel+2+closeURL+1])), true
+}
+
+// wrap переносит слова по ширине. Первая строка начинается с prefix, остальные - с indent
+func (r *markdownRenderer) wrap(words []mdWord, prefix, indent string) {
+	if len(words) == 0 {
+		return
+	}
+	line := prefix
+	lineWidth := lipgloss.Width(prefix)
+	empty := true
+	limit := r.width
+
+	for _, word := range words {
+		for _, part := range splitLongWord(word.text, limit-lipgloss.Width(indent)) {
+			partWidth := lipgloss.Width(part)
+			gap := 0
+			if !empty && word.space {
+				gap = 1
+			}
+			if limit > 0 && !empty && lineWidth+gap+partWidth > limit {
+				r.lines = append(r.lines, line)
+				line, lineWidth, empty, gap = indent, lipgloss.Width(indent), true, 0
+			}
+			if gap > 0 {
+				line += " "
+			}
+			line += word.style.Render(part)
+			lineWidth += gap + partWidth
+			empty = false
+			// Следующие части длинного слова идут без пробела
+			word.space = false
+		}
+		word.space = true
+	}
+	r.lines = append(r.lines, line)
+}
+
+// splitLongWord делит слово длиннее строки на части, чтобы оно не выходило за рамку
+func splitLongWord(word string, limit int) []string {
+	if limit <= 0 || lipgloss.Width(word) <= limit {
+		return []string{word}
+	}
+	var parts []string
+	var part strings.Builder
+	partWidth := 0
+	for _, ch := range word {
+		chWidth := lipgloss.Width(string(ch))
+		if partWidth+chWidth > limit && part.Len() > 0 {
+			parts = append(parts, part.String())
+			part.Reset()
+			partWidth = 0
+		}
+		part.WriteRune(ch)
+		partWidth += chWidth
+	}
+	return append(parts, part.String())
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderMarkdownWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		width  int
+	}{
+		{name: "абзац", source: "Исправлена ошибка, из-за которой игра закрывалась при загрузке сохранения на медленных дисках.", width: 24},
+		{name: "список", source: "- Новая подводная лодка с улучшенным сонаром и торпедами\n  - Вложенный пункт с длинным описанием изменений", width: 30},
+		{name: "нумерованный список", source: "1. Первое изменение с длинным описанием\n2. Второе изменение", width: 20},
+		{name: "цитата", source: "> Сервера будут недоступны во время переноса в новый центр обработки данных", width: 28},
+		{name: "выделение и ссылки", source: "**Важно:** прочитайте *подробности* в [новостях](https://example.com/news/2025/07) и `settings.yaml`", width: 26},
+		{name: "длинное слово", source: "Ссылка https://static.decembrist.org/submarine-game/windows/0.1.8/submarine.zip", width: 20},
+		{name: "заголовок", source: "# Версия 0.1.8 с большим количеством исправлений", width: 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := renderMarkdown(tt.source, tt.width, lipgloss.NewStyle())
+			for _, line := range strings.Split(rendered, "\n") {
+				if width := lipgloss.Width(line); width > tt.width {
+					t.Errorf("line %q is %d wide, limit %d", line, width, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownKeepsWords(t *testing.T) {
+	source := "Исправлена ошибка, из-за которой игра закрывалась при загрузке сохранения"
+	rendered := renderMarkdown(source, 20, lipgloss.NewStyle())
+	if got := strings.Join(strings.Fields(rendered), " "); got != source {
+		t.Errorf("words changed after wrapping: %q", got)
+	}
+	if lines := strings.Count(rendered, "\n") + 1; lines < 4 {
+		t.Errorf("text wrapped into %d lines, want at least 4", lines)
+	}
+}
+
+func TestRenderMarkdownNoWrap(t *testing.T) {
+	source := "Очень длинная строка, которая не переносится, потому что ширина не задана"
+	if rendered := renderMarkdown(source, 0, lipgloss.NewStyle()); strings.Contains(rendered, "\n") {
+		t.Errorf("renderMarkdown with width 0 wrapped the text: %q", rendered)
+	}
+}
+
+func TestRenderMarkdownListIndent(t *testing.T) {
+	rendered := renderMarkdown("- первый пункт списка переносится на несколько строк", 20, lipgloss.NewStyle())
+	lines := strings.Split(rendered, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("list item was not wrapped: %q", rendered)
+	}
+	if !strings.HasPrefix(lines[0], "• ") {
+		t.Errorf("first line %q has no bullet", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, "  ") {
+			t.Errorf("continuation line %q has no hanging indent", line)
+		}
+	}
+}
+
+func TestSplitLongWord(t *testing.T) {
+	tests := []struct {
+		word  string
+		limit int
+		want  []string
+	}{
+		{word: "короткое", limit: 10, want: []string{"короткое"}},
+		{word: "abcdefghij", limit: 4, want: []string{"abcd", "efgh", "ij"}},
+		{word: "abc", limit: 0, want: []string{"abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := splitLongWord(tt.word, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitLongWord(%q, %d) = %q, want %q", tt.word, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewsFileName - файл с идентификаторами прочитанных новостей рядом с файлом настроек
+const NewsFileName = "news.yaml"
+
+// Важность новости в манифесте
+const (
+	NewsSeverityInfo     = "info"
+	NewsSeverityWarning  = "warning"
+	NewsSeverityCritical = "critical"
+)
+
+// NewsItem - новость из манифеста: патч, событие, известная проблема
+type NewsItem struct {
+	ID        string        `yaml:"id" json:"id"`
+	Title     LocalizedText `yaml:"title" json:"title"`
+	Body      LocalizedText `yaml:"body,omitempty" json:"body,omitempty"`
+	Severity  string        `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Published *CustomTime   `yaml:"published,omitempty" json:"published,omitempty"`
+	Expires   *CustomTime   `yaml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+// Level возвращает уровень сообщения (Info, Warn, Error) по важности новости
+func (n NewsItem) Level() string {
+	switch n.Severity {
+	case NewsSeverityWarning:
+		return Warn
+	case NewsSeverityCritical:
+		return Error
+	default:
+		return Info
+	}
+}
+
+// IsActive проверяет, что новость уже опубликована и еще не истекла
+func (n NewsItem) IsActive(now time.Time) bool {
+	if n.Published != nil && now.Before(n.Published.Time) {
+		return false
+	}
+	if n.Expires != nil && !now.Before(n.Expires.Time) {
+		return false
+	}
+	return true
+}
+
+// ActiveNews возвращает действующие новости, новые сверху. Новости без id или
+// заголовка пропускаются: их нельзя отметить прочитанными или показать
+func ActiveNews(manifest *ManifestDto, now time.Time) []NewsItem {
+	if manifest == nil {
+		return nil
+	}
+	var news []NewsItem
+	for _, item := range manifest.News {
+		if item.ID == "" || item.Title.String() == "" || !item.IsActive(now) {
+			continue
+		}
+		news = append(news, item)
+	}
+	sort.SliceStable(news, func(i, j int) bool {
+		return newsPublished(news[i]).After(newsPublished(news[j]))
+	})
+	return news
+}
+
+// newsPublished возвращает дату публикации, новости без даты считаются самыми старыми
+func newsPublished(item NewsItem) time.Time {
+	if item.Published == nil {
+		return time.Time{}
+	}
+	return item.Published.Time
+}
+
+// newsState - содержимое файла прочитанных новостей
+type newsState struct {
+	Read []string `yaml:"read"`
+}
+
+// getNewsStatePath возвращает путь к файлу прочитанных новостей
+func getNewsStatePath() (string, error) {
+	configDir, err := getConfigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, NewsFileName), nil
+}
+
+// LoadReadNews возвращает идентификаторы прочитанных новостей. Если файл не удалось
+// прочитать, все новости считаются непрочитанными
+func LoadReadNews() map[string]bool {
+	read := make(map[string]bool)
+	path, err := getNewsStatePath()
+	if err != nil {
+		return read
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			LogLauncher("Новости: не удалось прочитать %s: %v", path, err)
+		}
+		return read
+	}
+	var state newsState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		LogLauncher("Новости: не удалось разобрать %s: %v", path, err)
+		return read
+	}
+	for _, id := range state.Read {
+		read[id] = true
+	}
+	return read
+}
+
+// SaveReadNews атомарно записывает прочитанные новости. Сохраняются только идентификаторы
+// действующих новостей, чтобы файл не рос со временем
+func SaveReadNews(read map[string]bool, news []NewsItem) error {
+	path, err := getNewsStatePath()
+	if err != nil {
+		return err
+	}
+	var state newsState
+	for _, item := range news {
+		if read[item.ID] {
+			state.Read = append(state.Read, item.ID)
+		}
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return newError("err.news.write", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return newError("err.news.write", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return newError("err.news.write", err)
+	}
+	return nil
+}
+
+// CountUnreadNews возвращает число непрочитанных новостей
+func CountUnreadNews(news []NewsItem, read map[string]bool) int {
+	unread := 0
+	for _, item := range news {
+		if !read[item.ID] {
+			unread++
+		}
+	}
+	return unread
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ErrDirNotWritable - в папку нельзя записывать файлы
+var ErrDirNotWritable = newError("err.dir.not_writable")
+
+// PortableMarkerFileName - файл рядом с лаунчером, включающий портативный режим:
+// игра, логи и настройки хранятся рядом с лаунчером, как в ранних версиях
+const PortableMarkerFileName = "portable.txt"
+
+// IsPortableMode проверяет, лежит ли рядом с лаунчером файл портативного режима
+func IsPortableMode() bool {
+	launcherPath, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(filepath.Dir(launcherPath), PortableMarkerFileName))
+	return err == nil
+}
+
+// useXDGLayout сообщает, что данные лаунчера раскладываются по каталогам XDG.
+// Так лаунчер работает и из папки только для чтения (/opt, AppImage)
+func useXDGLayout() bool {
+	return runtime.GOOS == "linux" && !IsPortableMode()
+}
+
+// xdgDir возвращает базовый каталог XDG из переменной окружения или значение по умолчанию
+// относительно домашней папки. Относительные пути по спецификации XDG игнорируются
+func xdgDir(env, fallback string) string {
+	if dir := os.Getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, LauncherConfigDirName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, fallback, LauncherConfigDirName)
+}
+
+// xdgGameDirPath возвращает папку игры в $XDG_DATA_HOME или пустую строку, если она недоступна
+func xdgGameDirPath(launcherPath string) string {
+	dataDir := xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+	if dataDir == "" {
+		return ""
+	}
+	// Игра, установленная рядом с лаунчером до перехода на XDG, остается на месте
+	legacyDir := legacyGameDirPath(launcherPath)
+	if hasGameInstall(legacyDir) && !hasGameInstall(filepath.Join(dataDir, GameFolderName)) {
+		return legacyDir
+	}
+	return filepath.Join(dataDir, GameFolderName)
+}
+
+// GetLogDirPath возвращает папку для логов игры и лаунчера
+func GetLogDirPath(gameDirPath string) string {
+	if useXDGLayout() {
+		if stateDir := xdgDir("XDG_STATE_HOME", filepath.Join(".local", "state")); stateDir != "" {
+			return filepath.Join(stateDir, "logs")
+		}
+	}
+	return filepath.Join(filepath.Dir(gameDirPath), "logs")
+}
+
+// GetDownloadDir возвращает папку для загружаемых архивов
+func GetDownloadDir() string {
+	if useXDGLayout() {
+		if cacheDir := xdgDir("XDG_CACHE_HOME", ".cache"); cacheDir != "" {
+			return filepath.Join(cacheDir, "downloads")
+		}
+	}
+	return os.TempDir()
+}
+
+// getCacheDirPath возвращает папку для кэша лаунчера: $XDG_CACHE_HOME в Linux,
+// иначе папку с файлом настроек
+func getCacheDirPath() (string, error) {
+	if useXDGLayout() {
+		if cacheDir := xdgDir("XDG_CACHE_HOME", ".cache"); cacheDir != "" {
+			return cacheDir, nil
+		}
+	}
+	return getConfigDirPath()
+}
+
+// archiveSearchDirs возвращает папки, где могут остаться загруженные архивы,
+// включая временную папку, которую использовали ранние версии
+func archiveSearchDirs() []string {
+	downloadDir := GetDownloadDir()
+	if downloadDir == os.TempDir() {
+		return []string{downloadDir}
+	}
+	return []string{downloadDir, os.TempDir()}
+}
+
+// getConfigDirPath возвращает папку для файла настроек. В портативном режиме настройки
+// лежат рядом с лаунчером, если туда можно писать
+func getConfigDirPath() (string, error) {
+	if IsPortableMode() {
+		launcherPath, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		if CheckLauncherDirWritable(launcherPath) == nil {
+			return filepath.Dir(launcherPath), nil
+		}
+	}
+	// На Linux учитывает $XDG_CONFIG_HOME
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, LauncherConfigDirName), nil
+}
+
+// userDataGameDirPath возвращает папку игры в данных пользователя, куда всегда можно писать:
+// $XDG_DATA_HOME в Linux, %LocalAppData% в Windows, Application Support в macOS
+func userDataGameDirPath() string {
+	var baseDir string
+	switch runtime.GOOS {
+	case "linux":
+		baseDir = xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+	case "windows":
+		if dir, err := os.UserCacheDir(); err == nil {
+			baseDir = filepath.Join(dir, LauncherConfigDirName)
+		}
+	default:
+		if dir, err := os.UserConfigDir(); err == nil {
+			baseDir = filepath.Join(dir, LauncherConfigDirName)
+		}
+	}
+	if baseDir == "" {
+		return ""
+	}
+	return filepath.Join(baseDir, GameFolderName)
+}
+
+// CheckLauncherDirWritable проверяет, можно ли писать в папку лаунчера.
+// Без этого не работает самообновление
+func CheckLauncherDirWritable(launcherPath string) error {
+	return checkDirWritable(filepath.Dir(launcherPath))
+}
+
+// CheckGameDirWritable проверяет, можно ли писать в папку игры, и объясняет проблему
+func CheckGameDirWritable(gameDirPath string) error {
+	if err := checkDirWritable(gameDirPath); err != nil {
+		if errors.Is(err, ErrDirNotWritable) {
+			return newError("err.dir.game_not_writable", gameDirPath)
+		}
+		return err
+	}
+	return nil
+}
+
+// ManualUpdateHint объясняет, почему самообновление отключено и как обновить лаунчер вручную
+func ManualUpdateHint(launcherPath string) string {
+	return T("launcher.manual_update_hint", filepath.Dir(launcherPath), GetLauncherURL(), launcherPath)
+}
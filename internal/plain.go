@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// PlainFlag - флаг командной строки для принудительного текстового режима
+const PlainFlag = "--plain"
+
+// plainMode - вывод построчным текстом без цветов, альтернативного экрана и вопросов пользователю
+var plainMode bool
+
+// DetectPlainMode включает текстовый режим, если вывод или ввод не является терминалом,
+// задана переменная NO_COLOR или передан флаг --plain. Возвращает аргументы без этого флага
+func DetectPlainMode(args []string) []string {
+	rest, plain := detectPlainMode(args, isTerminal(os.Stdout) && isTerminal(os.Stdin))
+	if plain {
+		plainMode = true
+	}
+	return rest
+}
+
+// detectPlainMode решает, нужен ли текстовый режим, по аргументам, окружению и наличию терминала
+func detectPlainMode(args []string, terminal bool) ([]string, bool) {
+	plain := !terminal
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == PlainFlag {
+			plain = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		plain = true
+	}
+	return rest, plain
+}
+
+// IsPlainMode сообщает, работает ли лаунчер в текстовом режиме
+func IsPlainMode() bool {
+	return plainMode
+}
+
+// isTerminal проверяет, подключен ли файл к терминалу
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// plainLevelPrefix возвращает текстовую метку уровня сообщения
+func plainLevelPrefix(level string) string {
+	switch level {
+	case Error:
+		return T("plain.level.error")
+	case Warn:
+		return T("plain.level.warn")
+	case Success:
+		return T("plain.level.success")
+	default:
+		return T("plain.level.info")
+	}
+}
+
+// printPlainMessage печатает сообщение одной строкой: ошибки и предупреждения в stderr
+func printPlainMessage(level, message string) {
+	var w io.Writer = os.Stdout
+	if level == Error || level == Warn {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "%s %s\n", plainLevelPrefix(level), message)
+}
+
+// lastPlainPercent - последний напечатанный процент, чтобы не повторять одинаковые строки
+var lastPlainPercent = -1
+
+// printPlainProgress печатает строку прогресса при изменении процента
+func printPlainProgress(percent int, message string) {
+	if percent == lastPlainPercent {
+		return
+	}
+	lastPlainPercent = percent
+	if message == "" {
+		fmt.Printf("[%3d%%]\n", percent)
+		return
+	}
+	fmt.Printf("[%3d%%] %s\n", percent, message)
+}
+
+// RunPlainLauncher - лаунчер без интерфейса. Вместо меню выполняется действие по умолчанию:
+// игра устанавливается или обновляется при необходимости и запускается.
+// Самообновление лаунчера только предлагается, а занятая директория игры не ожидается
+func RunPlainLauncher() int {
+	env := &cliEnv{stdout: os.Stdout, stderr: os.Stderr}
+
+	launcherPath, err := os.Executable()
+	if err != nil {
+		return env.fail(ExitError, "launcher.executable_path_error", err)
+	}
+	env.launcherPath = launcherPath
+
+	// Манифест проверяется раньше всего: эта версия лаунчера может быть заблокирована
+	manifest, manifestErr := GetRemoteManifest()
+	if block := LauncherBlockFor(manifest); block != nil {
+		if block.Action == BlockActionForceUpdate {
+			return env.fail(ExitLauncherBlocked, "cli.launcher_blocked", block.Text())
+		}
+		env.warn("cli.error", block.Text())
+	}
+
+	env.gameDirPath = GetGameDirPath(launcherPath)
+	env.progress = env.printProgress()
+
+	if err := CheckGameDirWritable(env.gameDirPath); err != nil {
+		// Без вопросов можно сменить только пустую папку, установленную игру переносит команда move
+		if IsGameDirOverridden() || hasGameInstall(env.gameDirPath) {
+			return env.fail(ExitError, "plain.game_dir_read_only", err)
+		}
+		dir, resolveErr := ResolveInstallDir(userDataGameDirPath())
+		if resolveErr != nil {
+			return env.fail(ExitError, "plain.fallback_dir_failed", err, resolveErr)
+		}
+		env.warn("plain.fallback_dir", err, dir)
+		if err := RememberGameDir(launcherPath, dir); err != nil {
+			env.warn("launcher.remember_dir_failed", err)
+		}
+		env.gameDirPath = dir
+	}
+
+	if err := OpenLauncherLog(GetLogDirPath(env.gameDirPath)); err != nil {
+		env.warn("launcher.log_open_failed", err)
+	} else {
+		defer CloseLauncherLog()
+	}
+	LogLauncher("Запуск в текстовом режиме")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if id, skew := clockSkew(); id != "" {
+		env.warn(id, formatCountdown(skew))
+	}
+	if manifestErr != nil {
+		env.warn("launcher.manifest_failed", manifestErr)
+	} else if IsLauncherUpdateMandatory(manifest) {
+		return env.fail(ExitUpdateAvailable, "cli.launcher_update_required", LauncherVersion, manifest.Version.MinLauncher)
+	} else if NeedsLauncherUpdate(manifest) && CheckLauncherDirWritable(launcherPath) != nil {
+		env.warn("plain.launcher_update_manual",
+			LauncherVersion, manifest.Version.Launcher, ManualUpdateHint(launcherPath))
+	} else if NeedsLauncherUpdate(manifest) {
+		env.warn("plain.launcher_update",
+			LauncherVersion, manifest.Version.Launcher)
+	}
+
+	lock, code := env.acquireLock()
+	if code != ExitOK {
+		return code
+	}
+	defer lock.Release()
+	// Остатки прерванных операций убираем только под блокировкой, иначе они могут быть чужими
+	CleanupLeftovers(env.launcherPath, env.gameDirPath)
+
+	if manifest != nil {
+		if text, level := GetServerMessage(manifest); text != "" {
+			printPlainMessage(level, text)
+		}
+		if text, level := GetMaintenanceMessage(manifest); text != "" {
+			printPlainMessage(level, text)
+		}
+	}
+
+	state, err := DetectGameState(env.gameDirPath, manifest)
+	if err != nil {
+		return env.fail(ExitError, "cli.error", err)
+	}
+	if lock.IsGameRunning() {
+		return env.fail(ExitLocked, "launcher.game_running")
+	}
+
+	updatesBlocked := GameUpdatesBlocked(manifest)
+	switch {
+	case !state.Installed && updatesBlocked:
+		return env.fail(ExitLauncherBlocked, "cli.launcher_blocked", LauncherBlockFor(manifest).Text())
+	case state.NeedsUpdate && updatesBlocked:
+		// Заблокированная версия лаунчера запускает установленную игру без обновления
+		env.warn("cli.launch_outdated", manifest.Version.Game, state.LocalVersion)
+	case !state.Installed:
+		if manifest == nil {
+			return env.fail(ExitNetwork, "plain.no_server")
+		}
+		env.info("plain.installing", manifest.Version.Game)
+		if err := createGameDirectory(env.gameDirPath); err != nil {
+			return env.fail(ExitError, "cli.create_dir_failed", err)
+		}
+		if code := env.runInstallOperation(ctx, "install", manifest); code != ExitOK {
+			return code
+		}
+	case state.NeedsUpdate:
+		if !IsGameAccessible(manifest) {
+			return env.fail(ExitMaintenance, "launcher.maintenance_blocked")
+		}
+		env.info("plain.updating", state.LocalVersion, manifest.Version.Game)
+		if code := env.runInstallOperation(ctx, "update", manifest); code != ExitOK {
+			return code
+		}
+	}
+
+	if manifest != nil && !IsGameAccessible(manifest) {
+		return env.fail(ExitMaintenance, "launcher.maintenance_blocked")
+	}
+	if err := TryRunGame(env.gameDirPath, lock); err != nil {
+		return ExitError
+	}
+	return ExitOK
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectPlainMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		noColor  bool
+		terminal bool
+		wantRest []string
+		want     bool
+	}{
+		{name: "терминал", args: []string{}, terminal: true, wantRest: []string{}, want: false},
+		{name: "нет терминала", args: []string{}, terminal: false, wantRest: []string{}, want: true},
+		{name: "флаг --plain", args: []string{PlainFlag}, terminal: true, wantRest: []string{}, want: true},
+		{name: "NO_COLOR", args: []string{}, noColor: true, terminal: true, wantRest: []string{}, want: true},
+		{name: "флаг среди аргументов", args: []string{"install", PlainFlag, "--json"}, terminal: true, wantRest: []string{"install", "--json"}, want: true},
+		{name: "подкоманда в терминале", args: []string{"status"}, terminal: true, wantRest: []string{"status"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			} else {
+				// t.Setenv восстановит прежнее значение после теста
+				t.Setenv("NO_COLOR", "")
+				os.Unsetenv("NO_COLOR")
+			}
+			rest, got := detectPlainMode(tt.args, tt.terminal)
+			if got != tt.want {
+				t.Errorf("detectPlainMode(%q, terminal %v) plain = %v, want %v", tt.args, tt.terminal, got, tt.want)
+			}
+			if strings.Join(rest, " ") != strings.Join(tt.wantRest, " ") {
+				t.Errorf("detectPlainMode(%q) rest = %q, want %q", tt.args, rest, tt.wantRest)
+			}
+		})
+	}
+}
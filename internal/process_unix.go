@@ -0,0 +1,88 @@
+//go:build !windows
+// +build !windows
+
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clockTicksPerSecond - единица времени в /proc/<pid>/stat. В Linux она почти всегда равна 100,
+// а без cgo значение sysconf(_SC_CLK_TCK) не узнать
+const clockTicksPerSecond = 100
+
+// isProcessAlive проверяет, существует ли процесс с указанным PID
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// Сигнал 0 не доставляется, но проверяет существование процесса
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// processStartTime возвращает время запуска процесса: в Linux из /proc, в остальных системах через ps
+func processStartTime(pid int) (time.Time, bool) {
+	if start, ok := procStartTime(pid); ok {
+		return start, true
+	}
+	return psStartTime(pid)
+}
+
+// procStartTime читает время запуска процесса из /proc/<pid>/stat и времени загрузки из /proc/stat
+func procStartTime(pid int) (time.Time, bool) {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Имя процесса в скобках может содержать пробелы, поля считаются после него
+	closing := strings.LastIndexByte(string(data), ')')
+	if closing == -1 {
+		return time.Time{}, false
+	}
+	fields := strings.Fields(string(data)[closing+1:])
+	// starttime - 22-е поле, а после имени идут поля начиная с 3-го
+	if len(fields) < 20 {
+		return time.Time{}, false
+	}
+	ticks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	stat, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, false
+	}
+	for _, line := range strings.Split(string(stat), "\n") {
+		if value, ok := strings.CutPrefix(line, "btime "); ok {
+			bootTime, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, false
+			}
+			offset := time.Duration(ticks) * time.Second / clockTicksPerSecond
+			return time.Unix(bootTime, 0).Add(offset), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// psStartTime узнает время запуска процесса командой ps, например в macOS
+func psStartTime(pid int) (time.Time, bool) {
+	cmd := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid))
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", strings.TrimSpace(string(output)), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
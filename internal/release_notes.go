@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Release - описание версии игры из манифеста: что изменилось и сколько загружать
+type Release struct {
+	Version   string        `yaml:"version" json:"version"`
+	Published *CustomTime   `yaml:"published,omitempty" json:"published,omitempty"`
+	Size      int64         `yaml:"size,omitempty" json:"size,omitempty"` // Размер архива в байтах
+	Notes     LocalizedText `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// ReleaseNotesFor возвращает описания версий новее localVersion вплоть до версии из манифеста,
+// новые сверху. Если установленную версию не удалось сравнить, показывается только последняя
+func ReleaseNotesFor(localVersion string, manifest *ManifestDto) []Release {
+	if manifest == nil {
+		return nil
+	}
+	target := manifest.Version.Game
+	var releases []Release
+	for _, release := range manifest.Releases {
+		if release.Version == "" || release.Notes.String() == "" {
+			continue
+		}
+		if release.Version == target {
+			releases = append(releases, release)
+			continue
+		}
+		newerThanLocal, err := IsVersionNewer(localVersion, release.Version)
+		if err != nil || !newerThanLocal {
+			continue
+		}
+		// Описания будущих версий, выложенные заранее, не показываются
+		if newerThanTarget, err := IsVersionNewer(target, release.Version); err != nil || newerThanTarget {
+			continue
+		}
+		releases = append(releases, release)
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		newer, err := IsVersionNewer(releases[j].Version, releases[i].Version)
+		return err == nil && newer
+	})
+	return releases
+}
+
+// findRelease возвращает описание указанной версии
+func findRelease(manifest *ManifestDto, version string) *Release {
+	if manifest == nil {
+		return nil
+	}
+	for i := range manifest.Releases {
+		if manifest.Releases[i].Version == version {
+			return &manifest.Releases[i]
+		}
+	}
+	return nil
+}
+
+// updateSizeTimeout ограничивает запрос размера архива, чтобы не задерживать меню
+const updateSizeTimeout = 10 * time.Second
+
+// GetUpdateSize возвращает размер архива новой версии игры: из манифеста, а если он там
+// не указан - по заголовку Content-Length сервера загрузок
+func GetUpdateSize(ctx context.Context, manifest *ManifestDto) (int64, error) {
+	if manifest != nil {
+		if release := findRelease(manifest, manifest.Version.Game); release != nil && release.Size > 0 {
+			return release.Size, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateSizeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, GetArchiveURL(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, newError("err.server_status", resp.StatusCode)
+	}
+	if resp.ContentLength <= 0 {
+		return 0, newError("err.update_size_unknown")
+	}
+	return resp.ContentLength, nil
+}
+
+// formatSize форматирует размер загрузки в мегабайтах или гигабайтах
+func formatSize(size int64) string {
+	const mb = 1024 * 1024
+	if size >= 1024*mb {
+		return T("size.gb", float64(size)/(1024*mb))
+	}
+	return T("size.mb", float64(size)/mb)
+}
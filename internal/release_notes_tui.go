@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ReleaseNotesModel - модель TUI со списком изменений перед обновлением игры
+type ReleaseNotesModel struct {
+	width        int
+	height       int
+	localVersion string
+	newVersion   string
+	size         int64 // Размер загрузки в байтах, 0 - неизвестен
+	releases     []Release
+	offset       int // Первая видимая строка списка изменений
+	confirmed    bool
+}
+
+// NewReleaseNotesModel создает экран изменений между установленной и новой версией
+func NewReleaseNotesModel(localVersion string, manifest *ManifestDto, size int64) ReleaseNotesModel {
+	return ReleaseNotesModel{
+		width:        80,
+		height:       24,
+		localVersion: localVersion,
+		newVersion:   manifest.Version.Game,
+		size:         size,
+		releases:     ReleaseNotesFor(localVersion, manifest),
+	}
+}
+
+func (m ReleaseNotesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ReleaseNotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.offset = m.clampOffset(m.offset)
+		return m, nil
+
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.offset = m.clampOffset(m.offset - 3)
+		case tea.MouseButtonWheelDown:
+			m.offset = m.clampOffset(m.offset + 3)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "enter":
+			m.confirmed = true
+			return m, tea.Quit
+		case "up", "k":
+			m.offset = m.clampOffset(m.offset - 1)
+		case "down", "j":
+			m.offset = m.clampOffset(m.offset + 1)
+		case "pgup", "b":
+			m.offset = m.clampOffset(m.offset - m.pageHeight())
+		case "pgdown", " ", "f":
+			m.offset = m.clampOffset(m.offset + m.pageHeight())
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = m.clampOffset(len(m.noteLines()))
+		}
+	}
+	return m, nil
+}
+
+// pageHeight возвращает число строк списка изменений, помещающихся на экране
+func (m ReleaseNotesModel) pageHeight() int {
+	// Логотип, заголовок в рамке, рамка списка и подвал занимают 14 строк
+	if height := m.height - 14; height > 3 {
+		return height
+	}
+	return 3
+}
+
+// clampOffset не дает прокрутить список за его начало и конец
+func (m ReleaseNotesModel) clampOffset(offset int) int {
+	maxOffset := len(m.noteLines()) - m.pageHeight()
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// noteWidth возвращает ширину текста внутри рамки списка изменений
+func (m ReleaseNotesModel) noteWidth() int {
+	if width := m.width - 16; width > 20 {
+		return width
+	}
+	return 20
+}
+
+// noteLines раскладывает описания версий по строкам с переносом по ширине экрана
+func (m ReleaseNotesModel) noteLines() []string {
+	versionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00D4AA")).Bold(true)
+	dateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+	var lines []string
+	for i, release := range m.releases {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		header := versionStyle.Render(T("release_notes.version", release.Version))
+		if release.Published != nil {
+			header += " " + dateStyle.Render(formatLocalTime(release.Published.Time))
+		}
+		lines = append(lines, header, "")
+		lines = append(lines, strings.Split(renderMarkdown(release.Notes.String(), m.noteWidth(), textStyle), "\n")...)
+	}
+	return lines
+}
+
+func (m ReleaseNotesModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("menu.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+
+	title := T("release_notes.title", m.localVersion, m.newVersion)
+	if m.size > 0 {
+		title += " • " + T("release_notes.size", formatSize(m.size))
+	}
+	notice := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00D4AA")).
+		Bold(true).
+		Render("🆕 " + title)
+	statusBox := boxStyle.Width(m.width - 10).Render(notice)
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(statusBox) + "\n"
+
+	lines := m.noteLines()
+	end := m.offset + m.pageHeight()
+	if end > len(lines) {
+		end = len(lines)
+	}
+	visible := strings.Join(lines[m.offset:end], "\n")
+	if len(lines) > m.pageHeight() {
+		visible += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).
+			Render(T("release_notes.position", m.offset+1, end, len(lines)))
+	}
+	notesBox := boxStyle.Width(m.width - 10).Align(lipgloss.Left).Render(visible)
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(notesBox)
+	footer := footerStyle.Width(m.width).Render(T("release_notes.footer"))
+
+	result := content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+// RunReleaseNotesTUI показывает изменения перед обновлением игры. Возвращает true,
+// если пользователь подтвердил обновление, и сразу true, если описаний версий нет
+func RunReleaseNotesTUI(localVersion string, manifest *ManifestDto, size int64) (bool, error) {
+	if len(ReleaseNotesFor(localVersion, manifest)) == 0 {
+		return true, nil
+	}
+	model := NewReleaseNotesModel(localVersion, manifest, size)
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, err
+	}
+	return finalModel.(ReleaseNotesModel).confirmed, nil
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// InstallIDFileName - файл с анонимным идентификатором установки рядом с файлом настроек
+const InstallIDFileName = "install-id"
+
+// rolloutBuckets - на сколько корзин делятся установки, 10000 дает шаг в 0.01%
+const rolloutBuckets = 10000
+
+// Rollout - постепенный выпуск версии: новую версию получает только percent процентов установок.
+// Остальные остаются на версии из раздела version и скачивают прежние файлы
+type Rollout struct {
+	Version string  `yaml:"version" json:"version"`
+	Percent float64 `yaml:"percent" json:"percent"`
+}
+
+// ManifestRollouts - постепенные выпуски игры и лаунчера
+type ManifestRollouts struct {
+	Game     *Rollout `yaml:"game,omitempty" json:"game,omitempty"`
+	Launcher *Rollout `yaml:"launcher,omitempty" json:"launcher,omitempty"`
+}
+
+var (
+	installIDMu sync.Mutex
+	installID   string // Прочитанный или созданный идентификатор установки
+
+	rolloutMu sync.Mutex
+	// rolloutVersions - версии из постепенного выпуска, которые получает эта установка.
+	// Файлы таких версий скачиваются из подпапки с номером версии
+	rolloutVersions = map[string]string{}
+)
+
+// getInstallIDPath возвращает путь к файлу идентификатора установки
+func getInstallIDPath() (string, error) {
+	configDir, err := getConfigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, InstallIDFileName), nil
+}
+
+// GetInstallID возвращает анонимный идентификатор установки и создает его при первом обращении.
+// Идентификатор - случайное число, он не связан ни с игроком, ни с компьютером
+func GetInstallID() (string, error) {
+	installIDMu.Lock()
+	defer installIDMu.Unlock()
+	if installID != "" {
+		return installID, nil
+	}
+
+	path, err := getInstallIDPath()
+	if err != nil {
+		return "", err
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			installID = id
+			return installID, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", newError("err.install_id.read", err)
+	}
+
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(raw)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", newError("err.install_id.write", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(id+"\n"), 0644); err != nil {
+		return "", newError("err.install_id.write", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", newError("err.install_id.write", err)
+	}
+	installID = id
+	return installID, nil
+}
+
+// inRolloutBucket проверяет, попадает ли установка в первые percent процентов выпуска.
+// В хэш входит версия, поэтому каждую версию первыми получают разные игроки, а при
+// увеличении процента уже получившие версию установки остаются в выпуске
+func inRolloutBucket(id, kind, version string, percent float64) bool {
+	if percent >= 100 {
+		return true
+	}
+	if percent <= 0 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(id + ":" + kind + ":" + version))
+	bucket := binary.BigEndian.Uint64(sum[:8]) % rolloutBuckets
+	return float64(bucket) < percent*rolloutBuckets/100
+}
+
+// rolloutApplies решает, получает ли эта установка версию из постепенного выпуска.
+// Выпуск версии не новее основной пропускается, чтобы опечатка в манифесте не откатила игроков.
+// Без идентификатора установки выпуск не применяется: корзина была бы случайной при каждом запуске
+func rolloutApplies(kind string, rollout *Rollout, current string) bool {
+	if rollout == nil || rollout.Version == "" || rollout.Version == current {
+		return false
+	}
+	if newer, err := IsVersionNewer(current, rollout.Version); err != nil || !newer {
+		LogLauncher("Постепенный выпуск: версия %s %s не новее %s, пропускаем", kind, rollout.Version, current)
+		return false
+	}
+	id, err := GetInstallID()
+	if err != nil {
+		LogLauncher("Постепенный выпуск: нет идентификатора установки, %s остается на версии %s: %v", kind, current, err)
+		return false
+	}
+	return inRolloutBucket(id, kind, rollout.Version, rollout.Percent)
+}
+
+// withRollouts возвращает копию манифеста, в которой версии игры и лаунчера заменены версиями
+// из постепенного выпуска, если установка в него попадает, и запоминает их для адресов загрузки.
+// Вызывается только при получении манифеста для работы: разбор манифеста побочных эффектов
+// не имеет, а сам манифест не меняется, поэтому выпуск можно применить к нему повторно
+func (m *ManifestDto) withRollouts() *ManifestDto {
+	resolved := *m
+	versions := map[string]string{}
+	if m.Rollout != nil {
+		if rolloutApplies(UpdateKindGame, m.Rollout.Game, m.Version.Game) {
+			resolved.Version.Game = m.Rollout.Game.Version
+			versions[UpdateKindGame] = resolved.Version.Game
+		}
+		if rolloutApplies(UpdateKindLauncher, m.Rollout.Launcher, m.Version.Launcher) {
+			resolved.Version.Launcher = m.Rollout.Launcher.Version
+			versions[UpdateKindLauncher] = resolved.Version.Launcher
+		}
+	}
+
+	rolloutMu.Lock()
+	rolloutVersions = versions
+	rolloutMu.Unlock()
+	return &resolved
+}
+
+// InRollout сообщает, получает ли установка версию игры или лаунчера из постепенного выпуска
+func InRollout(kind string) bool {
+	rolloutMu.Lock()
+	defer rolloutMu.Unlock()
+	return rolloutVersions[kind] != ""
+}
+
+// rolloutURL возвращает адрес файла версии из постепенного выпуска: файл лежит в подпапке
+// с номером версии рядом с обычным (.../windows/0.1.8/submarine.zip). Вне выпуска адрес не меняется
+func rolloutURL(fileURL, kind string) string {
+	rolloutMu.Lock()
+	version := rolloutVersions[kind]
+	rolloutMu.Unlock()
+	if version == "" {
+		return fileURL
+	}
+	slash := strings.LastIndex(fileURL, "/")
+	if slash == -1 {
+		return fileURL
+	}
+	return fileURL[:slash+1] + url.PathEscape(version) + fileURL[slash:]
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInRolloutBucketBounds(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    bool
+	}{
+		{percent: 0, want: false},
+		{percent: -5, want: false},
+		{percent: 100, want: true},
+		{percent: 150, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.percent), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprintf("install-%d", i)
+				if got := inRolloutBucket(id, UpdateKindGame, "0.1.8", tt.percent); got != tt.want {
+					t.Fatalf("inRolloutBucket(%q, %v) = %v, want %v", id, tt.percent, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInRolloutBucketStable(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("install-%d", i)
+		first := inRolloutBucket(id, UpdateKindGame, "0.1.8", 37.5)
+		for j := 0; j < 3; j++ {
+			if got := inRolloutBucket(id, UpdateKindGame, "0.1.8", 37.5); got != first {
+				t.Fatalf("inRolloutBucket(%q) changed between calls: %v, then %v", id, first, got)
+			}
+		}
+	}
+}
+
+func TestInRolloutBucketMonotonic(t *testing.T) {
+	percents := []float64{0.5, 1, 5, 10, 25, 50, 75, 99.99}
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("install-%d", i)
+		in := false
+		for _, percent := range percents {
+			got := inRolloutBucket(id, UpdateKindLauncher, "0.0.14", percent)
+			if in && !got {
+				t.Fatalf("install %q left the rollout when the share grew to %v%%", id, percent)
+			}
+			in = got
+		}
+	}
+}
+
+func TestInRolloutBucketShare(t *testing.T) {
+	const installs = 10000
+	tests := []struct {
+		kind, version string
+		percent       float64
+	}{
+		{kind: UpdateKindGame, version: "0.1.8", percent: 10},
+		{kind: UpdateKindGame, version: "0.1.8", percent: 50},
+		{kind: UpdateKindLauncher, version: "0.0.14", percent: 25},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s %v", tt.kind, tt.version, tt.percent), func(t *testing.T) {
+			count := 0
+			for i := 0; i < installs; i++ {
+				if inRolloutBucket(fmt.Sprintf("install-%d", i), tt.kind, tt.version, tt.percent) {
+					count++
+				}
+			}
+			share := float64(count) * 100 / installs
+			if share < tt.percent-2 || share > tt.percent+2 {
+				t.Errorf("share = %.2f%%, want about %v%%", share, tt.percent)
+			}
+		})
+	}
+}
+
+func TestInRolloutBucketDiffersByVersion(t *testing.T) {
+	// Первыми разные версии получают разные установки
+	same := 0
+	const installs = 1000
+	for i := 0; i < installs; i++ {
+		id := fmt.Sprintf("install-%d", i)
+		if inRolloutBucket(id, UpdateKindGame, "0.1.8", 10) == inRolloutBucket(id, UpdateKindGame, "0.1.9", 10) {
+			same++
+		}
+	}
+	if same == installs {
+		t.Error("rollout buckets do not depend on the version")
+	}
+}
+
+func TestWithRolloutsSkipsOlderVersions(t *testing.T) {
+	installIDMu.Lock()
+	savedID := installID
+	installID = "test-install"
+	installIDMu.Unlock()
+	t.Cleanup(func() {
+		installIDMu.Lock()
+		installID = savedID
+		installIDMu.Unlock()
+		rolloutMu.Lock()
+		rolloutVersions = map[string]string{}
+		rolloutMu.Unlock()
+	})
+
+	tests := []struct {
+		name      string
+		rollout   string
+		wantGame  string
+		inRollout bool
+	}{
+		{name: "новая версия", rollout: "0.1.8", wantGame: "0.1.8", inRollout: true},
+		{name: "та же версия", rollout: "0.1.7", wantGame: "0.1.7", inRollout: false},
+		{name: "старая версия", rollout: "0.1.6", wantGame: "0.1.7", inRollout: false},
+		{name: "неверный номер", rollout: "0.1.x", wantGame: "0.1.7", inRollout: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &ManifestDto{Rollout: &ManifestRollouts{Game: &Rollout{Version: tt.rollout, Percent: 100}}}
+			manifest.Version.Game = "0.1.7"
+
+			resolved := manifest.withRollouts()
+			if resolved.Version.Game != tt.wantGame {
+				t.Errorf("Version.Game = %q, want %q", resolved.Version.Game, tt.wantGame)
+			}
+			if manifest.Version.Game != "0.1.7" {
+				t.Errorf("withRollouts changed the parsed manifest: %q", manifest.Version.Game)
+			}
+			if got := InRollout(UpdateKindGame); got != tt.inRollout {
+				t.Errorf("InRollout() = %v, want %v", got, tt.inRollout)
+			}
+		})
+	}
+}
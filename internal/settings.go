@@ -0,0 +1,440 @@
+package internal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SettingsFileName - файл настроек лаунчера в папке конфигурации пользователя
+	SettingsFileName = "settings.yaml"
+	// SettingsVersion - текущая версия формата файла настроек
+	SettingsVersion = 1
+	// LauncherConfigDirName - папка лаунчера внутри папки конфигурации пользователя
+	LauncherConfigDirName = "SubmarineLauncher"
+)
+
+// Settings - настройки лаунчера, которые пользователь может менять
+type Settings struct {
+	Version            int    `yaml:"version"`
+	GameDir            string `yaml:"game_dir,omitempty"`
+	Channel            string `yaml:"channel"`
+	Language           string `yaml:"language"`
+	BandwidthLimit     int    `yaml:"bandwidth_limit_kbps"`     // КБ/с, 0 - без ограничения
+	LogRetention       int    `yaml:"log_retention_days"`       // Дней, 0 - хранить все логи
+	ManifestRefresh    int    `yaml:"manifest_refresh_minutes"` // Минут, 0 - не обновлять манифест в меню
+	UpdateReminderDays int    `yaml:"update_reminder_days"`     // Дней до напоминания об отложенном обновлении
+	LaunchArgs         string `yaml:"launch_args,omitempty"`
+	Theme              string `yaml:"theme"`
+}
+
+// DefaultSettings возвращает настройки по умолчанию
+func DefaultSettings() Settings {
+	return Settings{
+		Version:            SettingsVersion,
+		Channel:            DefaultChannel,
+		Language:           LanguageAuto,
+		LogRetention:       30,
+		ManifestRefresh:    5,
+		UpdateReminderDays: 3,
+		Theme:              "dark",
+	}
+}
+
+// settingField описывает одну настройку: как ее показать, переопределить и проверить
+type settingField struct {
+	key     string   // Имя в файле настроек
+	title   string   // Идентификатор названия на экране настроек
+	hint    string   // Идентификатор подсказки на экране настроек и в справке по флагам
+	env     string   // Переменная окружения
+	flag    string   // Флаг командной строки
+	options []string // Допустимые значения, если настройка выбирается из списка
+	get     func(s *Settings) string
+	set     func(s *Settings, value string) error
+}
+
+var settingFields = []settingField{
+	{
+		key:   "game_dir",
+		title: "settings.game_dir.title",
+		hint:  "settings.game_dir.hint",
+		env:   "SUBMARINE_GAME_DIR",
+		flag:  "game-dir",
+		get:   func(s *Settings) string { return s.GameDir },
+		set: func(s *Settings, value string) error {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				s.GameDir = ""
+				return nil
+			}
+			path, err := filepath.Abs(value)
+			if err != nil {
+				return newError("err.settings.invalid_path", err)
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return newError("err.settings.not_a_dir", path)
+			}
+			s.GameDir = path
+			return nil
+		},
+	},
+	{
+		key:   "channel",
+		title: "settings.channel.title",
+		hint:  "settings.channel.hint",
+		env:   "SUBMARINE_CHANNEL",
+		flag:  "channel",
+		get:   func(s *Settings) string { return s.Channel },
+		set: func(s *Settings, value string) error {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				value = DefaultChannel
+			}
+			if !channelNamePattern.MatchString(value) {
+				return newError("err.settings.invalid_channel", value)
+			}
+			s.Channel = value
+			return nil
+		},
+	},
+	{
+		key:     "language",
+		title:   "settings.language.title",
+		hint:    "settings.language.hint",
+		env:     "SUBMARINE_LANGUAGE",
+		flag:    "language",
+		options: []string{LanguageAuto, LanguageRu, LanguageEn},
+		get:     func(s *Settings) string { return s.Language },
+		set: func(s *Settings, value string) error {
+			if value == "" {
+				value = LanguageAuto
+			}
+			if !isSettingOption(value, LanguageAuto, LanguageRu, LanguageEn) {
+				return newError("err.settings.unknown_language", value)
+			}
+			s.Language = value
+			return nil
+		},
+	},
+	{
+		key:   "bandwidth_limit_kbps",
+		title: "settings.bandwidth.title",
+		hint:  "settings.bandwidth.hint",
+		env:   "SUBMARINE_BANDWIDTH_LIMIT",
+		flag:  "bandwidth-limit",
+		get:   func(s *Settings) string { return strconv.Itoa(s.BandwidthLimit) },
+		set: func(s *Settings, value string) error {
+			limit, err := parseNonNegative(value)
+			if err != nil {
+				return err
+			}
+			s.BandwidthLimit = limit
+			return nil
+		},
+	},
+	{
+		key:   "log_retention_days",
+		title: "settings.log_retention.title",
+		hint:  "settings.log_retention.hint",
+		env:   "SUBMARINE_LOG_RETENTION",
+		flag:  "log-retention",
+		get:   func(s *Settings) string { return strconv.Itoa(s.LogRetention) },
+		set: func(s *Settings, value string) error {
+			days, err := parseNonNegative(value)
+			if err != nil {
+				return err
+			}
+			s.LogRetention = days
+			return nil
+		},
+	},
+	{
+		key:   "manifest_refresh_minutes",
+		title: "settings.manifest_refresh.title",
+		hint:  "settings.manifest_refresh.hint",
+		env:   "SUBMARINE_MANIFEST_REFRESH",
+		flag:  "manifest-refresh",
+		get:   func(s *Settings) string { return strconv.Itoa(s.ManifestRefresh) },
+		set: func(s *Settings, value string) error {
+			minutes, err := parseNonNegative(value)
+			if err != nil {
+				return err
+			}
+			s.ManifestRefresh = minutes
+			return nil
+		},
+	},
+	{
+		key:   "update_reminder_days",
+		title: "settings.update_reminder.title",
+		hint:  "settings.update_reminder.hint",
+		env:   "SUBMARINE_UPDATE_REMINDER",
+		flag:  "update-reminder",
+		get:   func(s *Settings) string { return strconv.Itoa(s.UpdateReminderDays) },
+		set: func(s *Settings, value string) error {
+			days, err := parseNonNegative(value)
+			if err != nil {
+				return err
+			}
+			s.UpdateReminderDays = days
+			return nil
+		},
+	},
+	{
+		key:   "launch_args",
+		title: "settings.launch_args.title",
+		hint:  "settings.launch_args.hint",
+		env:   "SUBMARINE_LAUNCH_ARGS",
+		flag:  "launch-args",
+		get:   func(s *Settings) string { return s.LaunchArgs },
+		set: func(s *Settings, value string) error {
+			s.LaunchArgs = strings.Join(strings.Fields(value), " ")
+			return nil
+		},
+	},
+	{
+		key:     "theme",
+		title:   "settings.theme.title",
+		hint:    "settings.theme.hint",
+		env:     "SUBMARINE_THEME",
+		flag:    "theme",
+		options: []string{"dark", "light"},
+		get:     func(s *Settings) string { return s.Theme },
+		set: func(s *Settings, value string) error {
+			if !isSettingOption(value, "dark", "light") {
+				return newError("err.settings.unknown_theme", value)
+			}
+			s.Theme = value
+			return nil
+		},
+	},
+}
+
+func isSettingOption(value string, options ...string) bool {
+	for _, option := range options {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNonNegative(value string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || number < 0 {
+		return 0, newError("err.settings.not_non_negative", value)
+	}
+	return number, nil
+}
+
+// settingOverride - значение настройки из переменной окружения или флага
+type settingOverride struct {
+	value    string
+	sourceID string // Идентификатор сообщения с видом источника: флаг или переменная
+	name     string // Имя флага или переменной
+}
+
+// source описывает, откуда взято значение, на текущем языке
+func (o settingOverride) source() string {
+	if o.sourceID == "" {
+		return ""
+	}
+	return T(o.sourceID, o.name)
+}
+
+var (
+	fileSettings     = DefaultSettings() // Настройки из файла
+	activeSettings   = DefaultSettings() // Настройки с учетом окружения и флагов
+	settingOverrides = map[string]settingOverride{}
+)
+
+// CurrentSettings возвращает действующие настройки лаунчера
+func CurrentSettings() Settings {
+	return activeSettings
+}
+
+// GetSettingsPath возвращает путь к файлу настроек в папке конфигурации пользователя,
+// а в портативном режиме - рядом с лаунчером
+func GetSettingsPath() (string, error) {
+	configDir, err := getConfigDirPath()
+	if err != nil {
+		return "", newError("err.settings.config_dir", err)
+	}
+	return filepath.Join(configDir, SettingsFileName), nil
+}
+
+// readSettingsFile читает файл настроек. Отсутствующий файл - это настройки по умолчанию
+func readSettingsFile(path string) (Settings, error) {
+	settings := DefaultSettings()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, newError("err.settings.read", err)
+	}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return DefaultSettings(), newError("err.settings.parse", err)
+	}
+
+	// Файлы без версии записаны до появления поля version, их формат совпадает с первой версией
+	if settings.Version == 0 {
+		settings.Version = 1
+	}
+	if settings.Version > SettingsVersion {
+		LogLauncher("Настройки: файл версии %d записан более новым лаунчером, неизвестные поля игнорируются", settings.Version)
+	}
+	settings.Version = SettingsVersion
+
+	if err := validateSettings(&settings); err != nil {
+		return DefaultSettings(), err
+	}
+	return settings, nil
+}
+
+// validateSettings проверяет все настройки теми же правилами, что и ввод пользователя
+func validateSettings(settings *Settings) error {
+	for _, field := range settingFields {
+		if err := field.set(settings, field.get(settings)); err != nil {
+			return newError("err.settings.field", field.key, err)
+		}
+	}
+	return nil
+}
+
+// writeSettingsFile атомарно записывает файл настроек
+func writeSettingsFile(path string, settings Settings) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return newError("err.settings.create_dir", err)
+	}
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return newError("err.settings.write", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return newError("err.settings.write", err)
+	}
+	return nil
+}
+
+// RegisterSettingsFlags добавляет флаги, переопределяющие настройки, в набор флагов
+func RegisterSettingsFlags(flags *flag.FlagSet) {
+	for _, field := range settingFields {
+		field := field
+		usage := T("settings.flag_usage", T(field.title), T(field.hint))
+		flags.Func(field.flag, usage, func(value string) error {
+			var scratch Settings
+			if err := field.set(&scratch, value); err != nil {
+				return err
+			}
+			settingOverrides[field.key] = settingOverride{value: value, sourceID: "settings.source_flag", name: field.flag}
+			return nil
+		})
+	}
+}
+
+// LoadSettings загружает файл настроек и применяет переопределения.
+// Приоритет: флаги командной строки, затем переменные окружения, затем файл
+func LoadSettings() error {
+	path, err := GetSettingsPath()
+	if err == nil {
+		fileSettings, err = readSettingsFile(path)
+	}
+	if err != nil {
+		ShowStyledMessage(Warn, T("settings.defaults_used", err))
+	}
+
+	for _, field := range settingFields {
+		if _, ok := settingOverrides[field.key]; ok {
+			continue
+		}
+		if value, ok := os.LookupEnv(field.env); ok {
+			settingOverrides[field.key] = settingOverride{value: value, sourceID: "settings.source_env", name: field.env}
+		}
+	}
+	return applySettings()
+}
+
+// applySettings собирает действующие настройки и применяет их к лаунчеру
+func applySettings() error {
+	settings := fileSettings
+	for _, field := range settingFields {
+		override, ok := settingOverrides[field.key]
+		if !ok {
+			continue
+		}
+		if err := field.set(&settings, override.value); err != nil {
+			return newError("err.settings.field", override.source(), err)
+		}
+	}
+
+	if err := ApplyChannel(settings.Channel); err != nil {
+		return err
+	}
+	applyTheme(settings.Theme)
+	SetLanguage(settings.Language)
+	activeSettings = settings
+	return nil
+}
+
+// SaveSettings проверяет и сохраняет настройки в файл, после чего применяет их
+func SaveSettings(settings Settings) error {
+	if err := validateSettings(&settings); err != nil {
+		return err
+	}
+	path, err := GetSettingsPath()
+	if err != nil {
+		return err
+	}
+	settings.Version = SettingsVersion
+	if err := writeSettingsFile(path, settings); err != nil {
+		return err
+	}
+	fileSettings = settings
+	return applySettings()
+}
+
+// settingOverrideSource возвращает источник, переопределяющий настройку, или пустую строку
+func settingOverrideSource(key string) string {
+	return settingOverrides[key].source()
+}
+
+// IsCLICommand проверяет, запрошена ли подкоманда или справка, а не интерактивный лаунчер
+func IsCLICommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	return !strings.HasPrefix(args[0], "-") || args[0] == "-h" || args[0] == "--help"
+}
+
+// ParseLauncherFlags разбирает флаги интерактивного лаунчера и загружает настройки.
+// Ошибки сразу печатаются в stderr, как это делает пакет flag
+func ParseLauncherFlags(args []string) error {
+	flags := flag.NewFlagSet("SubmarineLauncher", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	RegisterSettingsFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		err := newError("err.settings.extra_args", strings.Join(flags.Args(), " "))
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	if err := LoadSettings(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	return nil
+}
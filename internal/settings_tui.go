@@ -0,0 +1,300 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SettingsModel - модель TUI для редактирования настроек лаунчера
+type SettingsModel struct {
+	width       int
+	height      int
+	draft       Settings // Редактируемые настройки из файла
+	cursor      int
+	editing     bool
+	input       []rune
+	message     string
+	messageType string
+	saved       bool
+	moveTo      string // Папка, в которую нужно перенести установленную игру
+	gameDirPath string // Папка игры, с которой работает лаунчер сейчас
+	defaultDir  string // Папка игры по умолчанию, рядом с лаунчером
+	installed   bool   // Игра установлена, смена папки означает перенос
+}
+
+// SettingsResult - итог работы экрана настроек
+type SettingsResult struct {
+	Saved  bool   // Настройки сохранены
+	MoveTo string // Пользователь выбрал новую папку для установленной игры
+}
+
+// NewSettingsModel создает экран настроек с текущим содержимым файла настроек
+func NewSettingsModel(gameDirPath, launcherPath string, installed bool) SettingsModel {
+	return SettingsModel{
+		width:       80,
+		height:      24,
+		draft:       fileSettings,
+		gameDirPath: gameDirPath,
+		defaultDir:  defaultGameDirPath(launcherPath),
+		installed:   installed,
+	}
+}
+
+// Пункты после списка настроек
+const (
+	settingsSaveItem = iota
+	settingsBackItem
+	settingsExtraItems
+)
+
+func (m SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateEditing(msg)
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(settingFields)+settingsExtraItems-1 {
+				m.cursor++
+			}
+		case "left", "h":
+			m.cycleOption(-1)
+		case "right", "l":
+			m.cycleOption(1)
+		case "enter", " ":
+			switch m.cursor - len(settingFields) {
+			case settingsSaveItem:
+				// Для переноса игры экран закрывается, перенос показывается отдельно
+				if m.save() && m.moveTo != "" {
+					return m, tea.Quit
+				}
+				return m, nil
+			case settingsBackItem:
+				return m, tea.Quit
+			}
+			field := settingFields[m.cursor]
+			if len(field.options) > 0 {
+				m.cycleOption(1)
+				return m, nil
+			}
+			m.editing = true
+			m.input = []rune(field.get(&m.draft))
+			m.message = ""
+		}
+	}
+	return m, nil
+}
+
+// updateEditing обрабатывает ввод значения текстовой настройки
+func (m SettingsModel) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.editing = false
+		m.message = ""
+	case tea.KeyEnter:
+		field := settingFields[m.cursor]
+		if err := field.set(&m.draft, string(m.input)); err != nil {
+			m.message = err.Error()
+			m.messageType = Error
+			return m, nil
+		}
+		m.editing = false
+		m.message = ""
+	default:
+		m.input = editRunes(m.input, msg)
+	}
+	return m, nil
+}
+
+// editRunes применяет к строке ввода нажатую клавишу: ввод символов, Backspace и Ctrl+U
+func editRunes(input []rune, msg tea.KeyMsg) []rune {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if len(input) > 0 {
+			return input[:len(input)-1]
+		}
+	case tea.KeyCtrlU:
+		return nil
+	case tea.KeySpace:
+		return append(input, ' ')
+	case tea.KeyRunes:
+		return append(input, msg.Runes...)
+	}
+	return input
+}
+
+// cycleOption переключает значение настройки со списком допустимых значений
+func (m *SettingsModel) cycleOption(step int) {
+	if m.cursor >= len(settingFields) {
+		return
+	}
+	field := settingFields[m.cursor]
+	if len(field.options) == 0 {
+		return
+	}
+	current := 0
+	for i, option := range field.options {
+		if option == field.get(&m.draft) {
+			current = i
+		}
+	}
+	next := (current + step + len(field.options)) % len(field.options)
+	field.set(&m.draft, field.options[next])
+	m.message = ""
+}
+
+// save сохраняет настройки. Новая папка для установленной игры не сохраняется сразу:
+// экран закрывается, и лаунчер переносит игру, а папку запоминает только после успешного переноса
+func (m *SettingsModel) save() bool {
+	draft := m.draft
+	target := draft.GameDir
+	if target == "" {
+		target = m.defaultDir
+	}
+
+	if !IsGameDirOverridden() && target != m.gameDirPath {
+		if m.installed {
+			moveTo, err := ResolveMoveTarget(m.gameDirPath, target)
+			if err != nil {
+				m.message = T("settings.move_rejected", err)
+				m.messageType = Error
+				return false
+			}
+			draft.GameDir = fileSettings.GameDir
+			m.moveTo = moveTo
+		} else {
+			dir, err := ResolveInstallDir(target)
+			if err != nil {
+				m.message = T("settings.bad_game_dir", err)
+				m.messageType = Error
+				return false
+			}
+			if dir == m.defaultDir {
+				dir = ""
+			}
+			draft.GameDir = dir
+		}
+	}
+
+	if err := SaveSettings(draft); err != nil {
+		m.message = T("settings.save_failed", err)
+		m.messageType = Error
+		m.moveTo = ""
+		return false
+	}
+	m.draft = draft
+	m.saved = true
+	m.message = T("settings.saved")
+	m.messageType = Success
+	return true
+}
+
+func (m SettingsModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("menu.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+	content += titleStyle.Width(m.width).Render(T("settings.title")) + "\n\n"
+
+	rows := ""
+	for i, field := range settingFields {
+		value := field.get(&m.draft)
+		if m.editing && m.cursor == i {
+			value = string(m.input) + "▏"
+		} else if value == "" {
+			value = "—"
+		} else if len(field.options) > 0 {
+			value = "◀ " + value + " ▶"
+		}
+		row := fmt.Sprintf("%-28s %s", T(field.title), value)
+		if source := settingOverrideSource(field.key); source != "" {
+			row += T("settings.overridden", source)
+		}
+		if m.cursor == i {
+			rows += selectedItemStyle.Render("▶ "+row) + "\n"
+		} else {
+			rows += menuItemStyle.Render("  "+row) + "\n"
+		}
+	}
+	rows += "\n"
+	for i, item := range []string{T("settings.save"), T("settings.back")} {
+		if m.cursor == len(settingFields)+i {
+			rows += selectedItemStyle.Render("▶ "+item) + "\n"
+		} else {
+			rows += menuItemStyle.Render("  "+item) + "\n"
+		}
+	}
+	settingsBox := boxStyle.Align(lipgloss.Left).Render(strings.TrimSuffix(rows, "\n"))
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(settingsBox) + "\n\n"
+
+	if m.cursor < len(settingFields) {
+		hint := statusStyle.Render(T(settingFields[m.cursor].hint))
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(hint) + "\n"
+	}
+
+	if m.message != "" {
+		var styled string
+		if m.messageType == Error {
+			styled = errorStyle.Render("❌ " + m.message)
+		} else {
+			styled = successStyle.Render("✅ " + m.message)
+		}
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(styled) + "\n"
+	}
+
+	var footer string
+	if m.editing {
+		footer = footerStyle.Width(m.width).Render(T("settings.footer_editing"))
+	} else {
+		footer = footerStyle.Width(m.width).Render(T("settings.footer"))
+	}
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+// RunSettingsTUI показывает экран настроек
+func RunSettingsTUI(gameDirPath, launcherPath string, installed bool) (SettingsResult, error) {
+	p := tea.NewProgram(NewSettingsModel(gameDirPath, launcherPath, installed), tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return SettingsResult{}, err
+	}
+	settingsModel := finalModel.(SettingsModel)
+	return SettingsResult{Saved: settingsModel.saved, MoveTo: settingsModel.moveTo}, nil
+}
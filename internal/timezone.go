@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"time"
+
+	// База часовых поясов для timezone манифеста: в Windows системной базы IANA нет
+	_ "time/tzdata"
+)
+
+// localTimeFormat - формат времени обслуживания и новостей в сообщениях, с часовым поясом
+const localTimeFormat = "2006-01-02 15:04 MST"
+
+// formatLocalTime записывает время в часовом поясе игрока с его обозначением
+func formatLocalTime(t time.Time) string {
+	return t.In(time.Local).Format(localTimeFormat)
+}
+
+// formatRelativeTime записывает время относительно now: "через 2 ч 15 мин" или "3 д 2 ч назад"
+func formatRelativeTime(t, now time.Time) string {
+	if t.After(now) {
+		return T("time.in", formatCountdown(t.Sub(now)))
+	}
+	return T("time.ago", formatCountdown(now.Sub(t)))
+}
+
+// resolveTimes уточняет время без смещения по часовому поясу манифеста и в строгом
+// режиме отклоняет неоднозначные значения
+func (m *ManifestDto) resolveTimes() error {
+	location := time.UTC
+	if m.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(m.TimeZone); err != nil {
+			return newError("err.time.unknown_zone", m.TimeZone)
+		}
+	}
+
+	times := []*CustomTime{m.ServerTime, m.Shutdown}
+	for i := range m.Maintenance {
+		times = append(times, &m.Maintenance[i].Start, m.Maintenance[i].End)
+	}
+	for i := range m.News {
+		times = append(times, m.News[i].Published, m.News[i].Expires)
+	}
+	for i := range m.Releases {
+		times = append(times, m.Releases[i].Published)
+	}
+	for _, t := range times {
+		if t == nil {
+			continue
+		}
+		if err := t.resolve(location, m.TimeZone != "", m.StrictTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve переводит время без смещения в часовой пояс location. В строгом режиме время без
+// смещения допустимо, только если timezone задан явно, и не должно попадать на перевод часов
+func (ct *CustomTime) resolve(location *time.Location, zoneSet, strict bool) error {
+	if !ct.zoneless {
+		return nil
+	}
+	if strict && !zoneSet {
+		return newError("err.time.no_zone", ct.raw)
+	}
+
+	wall := ct.Time
+	candidates := wallClockInstants(wall, location)
+	if strict {
+		switch len(candidates) {
+		case 0:
+			return newError("err.time.nonexistent", ct.raw, location)
+		case 1:
+		default:
+			return newError("err.time.ambiguous", ct.raw, location)
+		}
+	}
+	ct.Time = time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, location)
+	ct.zoneless = false
+	return nil
+}
+
+// wallClockInstants возвращает моменты, в которые часы в location показывают wall.
+// При переводе часов назад таких моментов два, при переводе вперед - ни одного
+func wallClockInstants(wall time.Time, location *time.Location) []time.Time {
+	var instants []time.Time
+	// Смещения пояса за сутки до и после покрывают один перевод часов
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := probe.In(location).Zone()
+		instant := wall.Add(-time.Duration(offset) * time.Second)
+		local := instant.In(location)
+		if local.Year() != wall.Year() || local.YearDay() != wall.YearDay() ||
+			local.Hour() != wall.Hour() || local.Minute() != wall.Minute() || local.Second() != wall.Second() {
+			continue
+		}
+		if len(instants) == 0 || !instants[0].Equal(instant) {
+			instants = append(instants, instant)
+		}
+	}
+	return instants
+}
@@ -1,10 +1,12 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"strings"
+	"time"
 )
 
 // Стили для интерфейса
@@ -61,6 +63,40 @@ var (
 			BorderForeground(lipgloss.Color("#21262D"))
 )
 
+// themePalette - цвета, которыми различаются темы оформления
+type themePalette struct {
+	background lipgloss.Color
+	text       lipgloss.Color
+	muted      lipgloss.Color
+	border     lipgloss.Color
+}
+
+var themes = map[string]themePalette{
+	"dark": {
+		background: lipgloss.Color("#0D1117"),
+		text:       lipgloss.Color("#FFFFFF"),
+		muted:      lipgloss.Color("#484F58"),
+		border:     lipgloss.Color("#21262D"),
+	},
+	"light": {
+		background: lipgloss.Color("#F6F8FA"),
+		text:       lipgloss.Color("#1F2328"),
+		muted:      lipgloss.Color("#8C959F"),
+		border:     lipgloss.Color("#D0D7DE"),
+	},
+}
+
+// applyTheme перекрашивает стили интерфейса в цвета выбранной темы
+func applyTheme(theme string) {
+	palette, ok := themes[theme]
+	if !ok {
+		palette = themes["dark"]
+	}
+	containerStyle = containerStyle.Background(palette.background)
+	menuItemStyle = menuItemStyle.Foreground(palette.text)
+	footerStyle = footerStyle.Foreground(palette.muted).BorderForeground(palette.border)
+}
+
 type MenuChoice int
 
 const (
@@ -76,24 +112,62 @@ type TUIModel struct {
 	gameInstalled bool
 	needsUpdate   bool
 	status        string
-	statusType    string       // Info, Warn, Error, Success
-	width         int          // Ширина терминала
-	height        int          // Высота терминала
-	selected      bool         // Был ли реально выбран пункт меню
-	manifest      *ManifestDto // Информация о версии для отображения уведомлений
+	statusType    string          // Info, Warn, Error, Success
+	width         int             // Ширина терминала
+	height        int             // Высота терминала
+	selected      bool            // Был ли реально выбран пункт меню
+	manifest      *ManifestDto    // Информация о версии для отображения уведомлений
+	readOnly      bool            // Игрой управляет другой лаунчер, действия недоступны
+	lockHolder    *LockInfo       // Владелец блокировки в режиме только для чтения
+	news          []NewsItem      // Действующие новости из манифеста
+	newsRead      map[string]bool // Прочитанные новости
+	newsCursor    int             // Выбранная новость
+	newsFocused   bool            // Стрелки листают новости, а не меню
+	maintenance   bool            // Идет техническое обслуживание: запуск и обновление недоступны
+	ticking       bool            // Запущен секундный таймер обратного отсчета
+	gameDirPath   string          // Папка игры для проверки версии после обновления манифеста
+	refreshEvery  time.Duration   // Период фонового обновления манифеста, 0 - не обновлять
+	localVersion  string          // Установленная версия игры для проверки min_game
+	updateSize    int64           // Размер загрузки обновления в байтах, 0 - пока неизвестен
 }
 
-func NewTUIModel(gameInstalled, needsUpdate bool, manifestDto *ManifestDto) TUIModel {
-	choices := []string{"🎮 Запустить игру", "🚪 Выход"}
+// maintenanceTickMsg обновляет обратный отсчет до начала или окончания обслуживания
+type maintenanceTickMsg time.Time
 
+// manifestRefreshDueMsg - пора запросить манифест заново
+type manifestRefreshDueMsg struct{}
+
+// manifestRefreshMsg - результат фонового запроса манифеста
+type manifestRefreshMsg struct {
+	manifest *ManifestDto
+	changed  bool
+	state    GameState
+	err      error
+}
+
+// updateSizeMsg - размер архива новой версии игры
+type updateSizeMsg struct {
+	version string
+	size    int64
+	err     error
+}
+
+// menuChoices возвращает пункты главного меню для состояния игры
+func menuChoices(gameInstalled, needsUpdate bool) []string {
 	if !gameInstalled {
-		choices = []string{"📦 Установить игру", "🚪 Выход"}
+		return []string{T("menu.install"), T("menu.settings"), T("menu.exit")}
 	} else if needsUpdate {
-		choices = []string{"🔄 Обновить игру", "🚪 Выход"}
+		return []string{T("menu.update"), T("menu.settings"), T("menu.exit")}
 	}
+	return []string{T("menu.run"), T("menu.settings"), T("menu.exit")}
+}
 
+// newsPageSize - сколько новостей помещается в ленту без прокрутки
+const newsPageSize = 3
+
+func NewTUIModel(gameInstalled, needsUpdate bool, manifestDto *ManifestDto) TUIModel {
 	return TUIModel{
-		choices:       choices,
+		choices:       menuChoices(gameInstalled, needsUpdate),
 		cursor:        0,
 		gameInstalled: gameInstalled,
 		needsUpdate:   needsUpdate,
@@ -102,11 +176,150 @@ func NewTUIModel(gameInstalled, needsUpdate bool, manifestDto *ManifestDto) TUIM
 		width:         80,          // Значение по умолчанию
 		height:        24,          // Значение по умолчанию
 		manifest:      manifestDto, // Будет установлено позже
+		news:          ActiveNews(manifestDto, ServerNow()),
+		newsRead:      LoadReadNews(),
+		maintenance:   manifestDto != nil && !IsGameAccessible(manifestDto),
+		ticking:       HasPendingMaintenance(manifestDto, ServerNow()),
 	}
 }
 
+// WithReadOnly переводит меню в режим только для чтения, пока игрой управляет другой лаунчер
+func (m TUIModel) WithReadOnly(holder *LockInfo) TUIModel {
+	m.readOnly = true
+	m.lockHolder = holder
+	m.choices = []string{T("menu.retry"), T("menu.exit")}
+	return m
+}
+
+// WithManifestRefresh включает фоновое обновление манифеста, пока меню открыто
+func (m TUIModel) WithManifestRefresh(gameDirPath string, interval time.Duration) TUIModel {
+	m.gameDirPath = gameDirPath
+	m.refreshEvery = interval
+	return m
+}
+
+// WithLocalVersion сообщает меню установленную версию игры, чтобы отличать обязательное обновление
+func (m TUIModel) WithLocalVersion(version string) TUIModel {
+	m.localVersion = version
+	return m
+}
+
+// WithStatus показывает в меню сообщение, например о недоступном самообновлении
+func (m TUIModel) WithStatus(message, msgType string) TUIModel {
+	m.status = message
+	m.statusType = msgType
+	return m
+}
+
 func (m TUIModel) Init() tea.Cmd {
-	return nil
+	var tick tea.Cmd
+	if m.ticking {
+		tick = maintenanceTick()
+	}
+	return tea.Batch(tick, m.scheduleManifestRefresh(), m.fetchUpdateSize())
+}
+
+// scheduleManifestRefresh откладывает следующий запрос манифеста на период обновления
+func (m TUIModel) scheduleManifestRefresh() tea.Cmd {
+	if m.refreshEvery <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshEvery, func(time.Time) tea.Msg {
+		return manifestRefreshDueMsg{}
+	})
+}
+
+// refreshManifest запрашивает манифест в фоне. Сервер отвечает 304, если манифест не менялся
+func (m TUIModel) refreshManifest() tea.Cmd {
+	gameDirPath := m.gameDirPath
+	return func() tea.Msg {
+		manifest, changed, err := RefreshManifest()
+		if err != nil || !changed {
+			return manifestRefreshMsg{err: err}
+		}
+		state, err := DetectGameState(gameDirPath, manifest)
+		return manifestRefreshMsg{manifest: manifest, changed: true, state: state, err: err}
+	}
+}
+
+// applyManifest показывает в меню новый манифест: пункты, уведомления, новости и обслуживание
+func (m TUIModel) applyManifest(manifest *ManifestDto, state GameState) (TUIModel, tea.Cmd) {
+	hadLauncherUpdate := m.manifest != nil && NeedsLauncherUpdate(m.manifest)
+	wasBlocked := LauncherBlockFor(m.manifest) != nil
+	newGameVersion := m.manifest == nil || m.manifest.Version.Game != manifest.Version.Game
+	m.manifest = manifest
+	m.localVersion = state.LocalVersion
+
+	// Заблокированной версии лаунчера обновлять игру нельзя
+	needsUpdate := state.NeedsUpdate && !GameUpdatesBlocked(manifest)
+	if needsUpdate && !m.needsUpdate && !IsGameUpdateMandatory(state.LocalVersion, manifest) {
+		// Необязательное обновление предлагается при следующем открытии меню, а пока игру можно запускать
+		needsUpdate = false
+		if newGameVersion {
+			m.status = T("menu.game_update_found", manifest.Version.Game)
+			m.statusType = Info
+		}
+	}
+	if !m.readOnly && state.Installed == m.gameInstalled && needsUpdate != m.needsUpdate {
+		m.needsUpdate = needsUpdate
+		m.choices = menuChoices(m.gameInstalled, m.needsUpdate)
+		if m.needsUpdate {
+			m.status = T("menu.game_update_found", manifest.Version.Game)
+			m.statusType = Info
+		}
+	}
+	if !hadLauncherUpdate && NeedsLauncherUpdate(manifest) {
+		m.status = T("menu.launcher_update_found", manifest.Version.Launcher)
+		m.statusType = Warn
+	}
+	if block := LauncherBlockFor(manifest); block != nil && !wasBlocked {
+		m.status = block.Text()
+		m.statusType = Error
+	}
+
+	m.news = ActiveNews(manifest, ServerNow())
+	if m.newsCursor >= len(m.news) {
+		m.newsCursor = 0
+		m.newsFocused = false
+	}
+
+	var sizeCmd tea.Cmd
+	if newGameVersion || m.updateSize == 0 {
+		m.updateSize = 0
+		sizeCmd = m.fetchUpdateSize()
+	}
+
+	// Таймер уже идет или новому манифесту он не нужен
+	m.maintenance = !IsGameAccessible(manifest)
+	if m.ticking || !HasPendingMaintenance(manifest, ServerNow()) {
+		return m, sizeCmd
+	}
+	m.ticking = true
+	return m, tea.Batch(sizeCmd, maintenanceTick())
+}
+
+// fetchUpdateSize узнает в фоне размер обновления, чтобы показать его рядом с пунктом меню
+func (m TUIModel) fetchUpdateSize() tea.Cmd {
+	if !m.needsUpdate || !m.gameInstalled || m.readOnly || m.manifest == nil {
+		return nil
+	}
+	manifest := m.manifest
+	return func() tea.Msg {
+		size, err := GetUpdateSize(context.Background(), manifest)
+		return updateSizeMsg{version: manifest.Version.Game, size: size, err: err}
+	}
+}
+
+// maintenanceTick запускает секундный таймер обратного отсчета до обслуживания
+func maintenanceTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return maintenanceTickMsg(t)
+	})
+}
+
+// playBlocked сообщает, что выбран запуск или обновление игры во время обслуживания
+func (m TUIModel) playBlocked() bool {
+	return m.maintenance && !m.readOnly && m.gameInstalled && m.cursor == 0
 }
 
 func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -116,10 +329,51 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
+	case maintenanceTickMsg:
+		// Когда обслуживание заканчивается, запуск становится доступен без перезапуска лаунчера
+		maintenance := !IsGameAccessible(m.manifest)
+		if m.maintenance && !maintenance && !m.readOnly {
+			m.status = T("menu.maintenance_over")
+			m.statusType = Success
+		}
+		m.maintenance = maintenance
+		// Таймер останавливается, когда обслуживание закончилось и новых окон нет
+		m.ticking = HasPendingMaintenance(m.manifest, ServerNow())
+		if !m.ticking {
+			return m, nil
+		}
+		return m, maintenanceTick()
+	case manifestRefreshDueMsg:
+		return m, m.refreshManifest()
+	case updateSizeMsg:
+		if msg.err != nil {
+			LogLauncher("Меню: не удалось узнать размер обновления: %v", msg.err)
+		} else if m.manifest != nil && msg.version == m.manifest.Version.Game {
+			m.updateSize = msg.size
+		}
+		return m, nil
+	case manifestRefreshMsg:
+		if msg.err != nil {
+			// Меню продолжает показывать прежние данные до следующей попытки
+			LogLauncher("Меню: не удалось обновить манифест: %v", msg.err)
+		} else if msg.changed {
+			var cmd tea.Cmd
+			m, cmd = m.applyManifest(msg.manifest, msg.state)
+			return m, tea.Batch(cmd, m.scheduleManifestRefresh())
+		}
+		return m, m.scheduleManifestRefresh()
 	case tea.KeyMsg:
+		if m.newsFocused {
+			return m.updateNews(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c", "q", "esc":
 			return m, tea.Quit
+		case "tab":
+			if len(m.news) > 0 {
+				m.newsFocused = true
+				return m, m.markNewsRead()
+			}
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
@@ -129,6 +383,11 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor++
 			}
 		case "enter", " ":
+			if m.playBlocked() {
+				m.status = T("launcher.maintenance_blocked")
+				m.statusType = Error
+				return m, nil
+			}
 			m.selected = true
 			return m, tea.Quit
 		}
@@ -136,12 +395,124 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateNews обрабатывает клавиши, пока открыта лента новостей
+func (m TUIModel) updateNews(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "tab", "esc":
+		m.newsFocused = false
+	case "up", "k":
+		if m.newsCursor > 0 {
+			m.newsCursor--
+			return m, m.markNewsRead()
+		}
+	case "down", "j":
+		if m.newsCursor < len(m.news)-1 {
+			m.newsCursor++
+			return m, m.markNewsRead()
+		}
+	}
+	return m, nil
+}
+
+// markNewsRead отмечает выбранную новость прочитанной и сохраняет это на диск
+func (m TUIModel) markNewsRead() tea.Cmd {
+	id := m.news[m.newsCursor].ID
+	if m.newsRead[id] {
+		return nil
+	}
+	m.newsRead[id] = true
+
+	// Запись идет в фоне, поэтому ей передается копия
+	read := make(map[string]bool, len(m.newsRead))
+	for readID := range m.newsRead {
+		read[readID] = true
+	}
+	news := m.news
+	return func() tea.Msg {
+		if err := SaveReadNews(read, news); err != nil {
+			LogLauncher("Новости: не удалось сохранить прочитанные: %v", err)
+		}
+		return nil
+	}
+}
+
+// renderNews рисует ленту новостей: заголовки с отметкой непрочитанных и текст выбранной
+func (m TUIModel) renderNews() string {
+	header := T("news.title")
+	if unread := CountUnreadNews(m.news, m.newsRead); unread > 0 {
+		header = T("news.title_unread", unread)
+	}
+	lines := []string{titleStyle.Padding(0).Render(header)}
+
+	offset := 0
+	if m.newsCursor >= newsPageSize {
+		offset = m.newsCursor - newsPageSize + 1
+	}
+	end := offset + newsPageSize
+	if end > len(m.news) {
+		end = len(m.news)
+	}
+	if offset > 0 {
+		lines = append(lines, statusStyle.Padding(0).Render(T("news.more_above", offset)))
+	}
+	for i := offset; i < end; i++ {
+		item := m.news[i]
+		marker := "  "
+		if !m.newsRead[item.ID] {
+			marker = "● "
+		}
+		line := marker + newsIcon(item) + item.Title.String()
+		if item.Published != nil {
+			line += "  " + T("news.published", formatLocalTime(item.Published.Time), formatRelativeTime(item.Published.Time, ServerNow()))
+		}
+		if m.newsFocused && i == m.newsCursor {
+			line = selectedItemStyle.Padding(0).Render(line)
+		} else if !m.newsRead[item.ID] {
+			line = lipgloss.NewStyle().Bold(true).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if end < len(m.news) {
+		lines = append(lines, statusStyle.Padding(0).Render(T("news.more_below", len(m.news)-end)))
+	}
+
+	if m.newsFocused {
+		item := m.news[m.newsCursor]
+		if body := item.Body.String(); body != "" {
+			// Рамка ленты шириной m.width-10 с полями по 2 символа
+			lines = append(lines, "", renderMarkdown(body, m.width-14, lipgloss.NewStyle()))
+		}
+		if item.Expires != nil {
+			expires := T("news.expires", formatLocalTime(item.Expires.Time), formatRelativeTime(item.Expires.Time, ServerNow()))
+			lines = append(lines, "", statusStyle.Padding(0).Render(expires))
+		}
+	} else {
+		lines = append(lines, "", statusStyle.Padding(0).Render(T("news.open_hint")))
+	}
+
+	return boxStyle.Width(m.width - 10).Align(lipgloss.Left).Render(strings.Join(lines, "\n"))
+}
+
+// newsIcon возвращает значок важности новости
+func newsIcon(item NewsItem) string {
+	switch item.Level() {
+	case Error:
+		return "❗ "
+	case Warn:
+		return "⚠️  "
+	default:
+		return "ℹ️  "
+	}
+}
+
 func (m TUIModel) View() string {
 	// Создаем главный контейнер
 	container := containerStyle.Width(m.width).Height(m.height)
 
 	// ASCII лого
-	logo := `🚢 СУБМАРИНА LAUNCHER 🚢`
+	logo := T("menu.logo")
 
 	// Создаем основной контент
 	content := ""
@@ -169,19 +540,34 @@ func (m TUIModel) View() string {
 		// Проверяем серверные сообщения
 		serverMsg, serverMsgType := GetServerMessage(m.manifest)
 		if serverMsg != "" {
-			var styledServerMsg string
+			serverMsgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD43B")).Bold(true)
 			if serverMsgType == Error {
-				styledServerMsg = errorStyle.Render("📢 " + serverMsg)
-			} else {
-				styledServerMsg = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("#FFD43B")).
-					Bold(true).
-					Render("📢 " + serverMsg)
+				serverMsgStyle = errorStyle
 			}
-			content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(styledServerMsg) + "\n\n"
+			// Текст в Markdown переносится по ширине окна, строки после первой выравниваются
+			// по тексту за значком. Строки дополняются до одной ширины, чтобы блок центрировался целиком
+			lines := strings.Split(renderMarkdown(serverMsg, m.width-13, serverMsgStyle), "\n")
+			for i := range lines {
+				if i == 0 {
+					lines[i] = serverMsgStyle.Render("📢 ") + lines[i]
+				} else {
+					lines[i] = "   " + lines[i]
+				}
+			}
+			block := lipgloss.NewStyle().Align(lipgloss.Left).Render(strings.Join(lines, "\n"))
+			content += lipgloss.PlaceHorizontal(m.width, lipgloss.Center, block) + "\n\n"
 		}
 	}
 
+	// Режим только для чтения
+	if m.readOnly {
+		readOnlyMsg := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFD43B")).
+			Bold(true).
+			Render(T("menu.read_only", describeLockHolder(m.lockHolder)))
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(readOnlyMsg) + "\n\n"
+	}
+
 	// Статус (показываем только если есть сообщение)
 	if m.status != "" {
 		var statusStyled string
@@ -190,6 +576,11 @@ func (m TUIModel) View() string {
 			statusStyled = errorStyle.Render("❌ " + m.status)
 		case Success:
 			statusStyled = successStyle.Render("✅ " + m.status)
+		case Warn:
+			statusStyled = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFD43B")).
+				Bold(true).
+				Render("⚠️  " + m.status)
 		default:
 			statusStyled = statusStyle.Render("ℹ️  " + m.status)
 		}
@@ -199,38 +590,61 @@ func (m TUIModel) View() string {
 	// Определяем состояние игры для отображения
 	var gameStatus string
 	if !m.gameInstalled {
-		gameStatus = "🔴 Игра не установлена"
+		gameStatus = T("menu.game_not_installed")
 	} else if m.needsUpdate {
-		gameStatus = "🟡 Доступно обновление"
+		gameStatus = T("menu.update_available")
+		if IsGameUpdateMandatory(m.localVersion, m.manifest) {
+			gameStatus = T("menu.game_update_required", m.manifest.Version.Game)
+		}
 	} else {
-		gameStatus = "🟢 Игра готова к запуску"
+		gameStatus = T("menu.game_ready")
 	}
 
 	// Отображаем статус игры
 	statusBox := boxStyle.Width(m.width - 10).Render(gameStatus)
 	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(statusBox) + "\n\n"
 
+	// Лента новостей
+	if len(m.news) > 0 {
+		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(m.renderNews()) + "\n\n"
+	}
+
 	// Меню
-	menuTitle := titleStyle.Width(m.width).Render("ВЫБЕРИТЕ ДЕЙСТВИЕ")
+	menuTitle := titleStyle.Width(m.width).Render(T("menu.title"))
 	content += menuTitle + "\n\n"
 
 	// Рендерим меню по центру
 	menu := ""
+	itemWidth := 30
 	for i, choice := range m.choices {
+		if i == 0 && m.needsUpdate && m.updateSize > 0 && !m.readOnly {
+			// Размер загрузки не помещается в обычную ширину пункта
+			choice += " · " + formatSize(m.updateSize)
+			itemWidth = 40
+		}
+		if i == 0 && m.maintenance && !m.readOnly && m.gameInstalled {
+			choice += " 🔒"
+		}
 		cursor := "  "
 		if m.cursor == i {
 			cursor = "▶ "
-			menu += selectedItemStyle.Width(30).Align(lipgloss.Center).Render(cursor+choice) + "\n"
+			menu += selectedItemStyle.Width(itemWidth).Align(lipgloss.Center).Render(cursor+choice) + "\n"
 		} else {
-			menu += menuItemStyle.Width(30).Align(lipgloss.Center).Render(cursor+choice) + "\n"
+			menu += menuItemStyle.Width(itemWidth).Align(lipgloss.Center).Render(cursor+choice) + "\n"
 		}
 	}
 
-	menuContainer := boxStyle.Width(40).Render(menu)
+	menuContainer := boxStyle.Width(itemWidth + 10).Render(menu)
 	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(menuContainer)
 
 	// Добавляем footer с подсказками
-	footer := footerStyle.Width(m.width).Render("↑/↓ - навигация • Enter - выбрать • Esc/Q - выход")
+	footerHint := T("menu.footer")
+	if m.newsFocused {
+		footerHint = T("news.footer")
+	} else if len(m.news) > 0 {
+		footerHint = T("menu.footer_news")
+	}
+	footer := footerStyle.Width(m.width).Render(footerHint)
 
 	// Вычисляем сколько пустых строк нужно добавить для центрирования
 	contentHeight := strings.Count(content, "\n") + 3 // +3 для footer
@@ -256,6 +670,21 @@ func (m TUIModel) GetChoice() int {
 	return m.cursor
 }
 
+// Manifest возвращает манифест, который показывало меню, с учетом фоновых обновлений
+func (m TUIModel) Manifest() *ManifestDto {
+	return m.manifest
+}
+
+// NeedsUpdate сообщает, предлагало ли меню обновление игры
+func (m TUIModel) NeedsUpdate() bool {
+	return m.needsUpdate
+}
+
+// UpdateSize возвращает размер обновления игры, если меню успело его узнать
+func (m TUIModel) UpdateSize() int64 {
+	return m.updateSize
+}
+
 func (m TUIModel) WasSelected() bool {
 	return m.selected
 }
@@ -267,6 +696,11 @@ func ShowProgress(current, total float64, message string) {
 		percent = 100
 	}
 
+	if plainMode {
+		printPlainProgress(percent, message)
+		return
+	}
+
 	barWidth := 40
 	filled := int(float64(barWidth) * float64(percent) / 100.0)
 
@@ -285,8 +719,20 @@ func ShowProgress(current, total float64, message string) {
 	}
 }
 
+// messageSink, если задан, получает сообщения ShowStyledMessage вместо вывода в терминал
+var messageSink func(level, message string)
+
 // Функция для отображения сообщения в красивом стиле
 func ShowStyledMessage(msgType, message string) {
+	if messageSink != nil {
+		messageSink(msgType, message)
+		return
+	}
+	if plainMode {
+		printPlainMessage(msgType, message)
+		return
+	}
+
 	var styledMsg string
 	switch msgType {
 	case Error:
@@ -307,9 +753,15 @@ func ShowStyledMessage(msgType, message string) {
 
 // Функция для подтверждения действия в красивом стиле
 func ShowConfirmDialog(message string) bool {
+	if plainMode {
+		// Спросить некого: действие не подтверждено
+		printPlainMessage(Warn, T("ui.confirm_no_terminal", message))
+		return false
+	}
+
 	confirmBox := fmt.Sprintf("%s\n\n%s",
 		message,
-		statusStyle.Render("y/н для подтверждения, любая другая клавиша для отмены"))
+		statusStyle.Render(T("ui.confirm_hint")))
 
 	fmt.Println(boxStyle.Render(confirmBox))
 
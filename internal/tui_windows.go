@@ -4,7 +4,6 @@
 package internal
 
 import (
-	"fmt"
 	"syscall"
 	"unsafe"
 )
@@ -26,19 +25,19 @@ const (
 func EnableWindowsColors() error {
 	handle, _, _ := procGetStdHandle.Call(stdOutputHandle)
 	if handle == 0 {
-		return fmt.Errorf("не удалось получить handle консоли")
+		return newError("err.console_handle")
 	}
 
 	var mode uint32
 	ret, _, _ := procGetConsoleMode.Call(handle, uintptr(unsafe.Pointer(&mode)))
 	if ret == 0 {
-		return fmt.Errorf("не удалось получить режим консоли")
+		return newError("err.console_mode_get")
 	}
 
 	mode |= enableVirtualTerminalProcessing
 	ret, _, _ = procSetConsoleMode.Call(handle, uintptr(mode))
 	if ret == 0 {
-		return fmt.Errorf("не удалось установить режим консоли")
+		return newError("err.console_mode_set")
 	}
 
 	return nil
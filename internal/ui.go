@@ -22,6 +22,10 @@ func drawProgress(downloaded, total float64) {
 	if percent > 100 {
 		percent = 100
 	}
+	if plainMode {
+		printPlainProgress(percent, "")
+		return
+	}
 	filled := int(float64(BarWidth) * float64(percent) / 100.0)
 	bar := GreenColor + strings.Repeat("█", filled) + ResetColor + strings.Repeat("█", BarWidth-filled)
 	fmt.Printf("\r%s %3d%%", bar, percent)
@@ -31,15 +35,22 @@ func ShowExitMessage(level, message string) {
 	if message != "" {
 		ShowStyledMessage(level, message)
 	}
-	fmt.Println("Нажмите Enter для выхода...")
+	// Без терминала ждать нажатия Enter некому
+	if plainMode {
+		return
+	}
+	fmt.Println(T("ui.press_enter_to_exit"))
 	fmt.Scanln()
 }
 
 func CheckAnswer() bool {
+	if plainMode {
+		return false
+	}
 	var answer string
 	_, err := fmt.Scanln(&answer)
 	if err != nil {
 		return false
 	}
-	return answer == "y" || answer == "Y" || answer == "н" || answer == "Н"
+	return isYesAnswer(answer)
 }
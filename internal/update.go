@@ -2,30 +2,142 @@ package internal
 
 import (
 	"archive/zip"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 const ArchiveNameTemplate = "submarine-archive-*.zip"
 
+// archiveMetaSuffix - суффикс файла с описанием загружаемого архива
+const archiveMetaSuffix = ".meta"
+
+// archiveMeta описывает загружаемый архив, чтобы прерванную загрузку можно было продолжить
+type archiveMeta struct {
+	URL          string `yaml:"url"`
+	ETag         string `yaml:"etag,omitempty"`
+	LastModified string `yaml:"last_modified,omitempty"`
+	Size         int64  `yaml:"size"`
+}
+
+func readArchiveMeta(archivePath string) (*archiveMeta, error) {
+	data, err := os.ReadFile(archivePath + archiveMetaSuffix)
+	if err != nil {
+		return nil, err
+	}
+	var meta archiveMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func writeArchiveMeta(archivePath string, meta archiveMeta) error {
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(archivePath+archiveMetaSuffix, data, 0644)
+}
+
+// openArchiveForDownload открывает прерванную загрузку для продолжения или создает новый временный файл.
+// Возвращает файл и количество уже загруженных байт
+func openArchiveForDownload() (*os.File, int64, error) {
+	if path := findResumableArchive(); path != "" {
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err == nil {
+			offset, err := file.Seek(0, io.SeekEnd)
+			if err == nil {
+				LogLauncher("Продолжаем прерванную загрузку %s с %d байт", path, offset)
+				return file, offset, nil
+			}
+			file.Close()
+		}
+	}
+
+	downloadDir := GetDownloadDir()
+	if err := os.MkdirAll(downloadDir, 0755); err != nil {
+		return nil, 0, newError("err.download.create_dir", err)
+	}
+	file, err := os.CreateTemp(downloadDir, ArchiveNameTemplate)
+	if err != nil {
+		return nil, 0, err
+	}
+	return file, 0, nil
+}
+
+// contextReader прерывает чтение, как только контекст отменен
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// throttledReader ограничивает скорость чтения заданным числом байт в секунду
+type throttledReader struct {
+	ctx         context.Context
+	r           io.Reader
+	bytesPerSec int64
+	started     time.Time
+	read        int64
+}
+
+// limitBandwidth ограничивает скорость загрузки согласно настройкам лаунчера
+func limitBandwidth(ctx context.Context, r io.Reader) io.Reader {
+	if activeSettings.BandwidthLimit <= 0 {
+		return r
+	}
+	return &throttledReader{ctx: ctx, r: r, bytesPerSec: int64(activeSettings.BandwidthLimit) * 1024, started: time.Now()}
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	// Читаем не больше, чем разрешено за секунду, чтобы не было резких всплесков
+	if int64(len(p)) > tr.bytesPerSec {
+		p = p[:tr.bytesPerSec]
+	}
+	n, err := tr.r.Read(p)
+	tr.read += int64(n)
+
+	expected := time.Duration(float64(tr.read) / float64(tr.bytesPerSec) * float64(time.Second))
+	if wait := expected - time.Since(tr.started); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-tr.ctx.Done():
+			return n, tr.ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return n, err
+}
+
 func removeOldFiles(dir, launcherPath string) error {
 	dirEntries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("ошибка при чтении директории %s: %v", dir, err)
+		return newError("err.read_dir", dir, err)
 	}
 	for _, entry := range dirEntries {
 		entryPath := filepath.Join(dir, entry.Name())
-		if entryPath == launcherPath {
+		if entryPath == launcherPath || isReservedEntry(entry.Name()) {
 			continue
 		}
 		err := os.RemoveAll(entryPath)
 		if err != nil {
-			return fmt.Errorf("ошибка при удалении файла %s: %v", entryPath, err)
+			return newError("err.remove_file", entryPath, err)
 		} else {
-			fmt.Printf("Удалено: %s\n", entryPath)
+			fmt.Println(T("update.removed", entryPath))
 		}
 	}
 	return nil
@@ -34,12 +146,12 @@ func removeOldFiles(dir, launcherPath string) error {
 func TryUnzipGame(dir, updaterPath string) error {
 	err := removeOldFiles(dir, updaterPath)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении старых файлов: %v", err)
+		return newError("err.update.remove_old", err)
 	}
 
 	archiveFile, err := os.CreateTemp("", ArchiveNameTemplate)
 	if err != nil {
-		return fmt.Errorf("ошибка при создании временного файла архива: %v", err)
+		return newError("err.install.create_archive", err)
 	}
 
 	archivePath := archiveFile.Name()
@@ -47,17 +159,17 @@ func TryUnzipGame(dir, updaterPath string) error {
 		archiveFile.Close()
 		err = os.Remove(archivePath)
 		if err != nil {
-			fmt.Println("Ошибка при удалении временного файла архива:", err)
+			fmt.Println(T("update.remove_temp_failed", err))
 		}
 	}()
 
 	err = downloadZip(archiveFile)
 	if err != nil {
-		return fmt.Errorf("ошибка при загрузке архива: %v", err)
+		return newError("err.install.download", err)
 	}
 
 	//todo
-	//err = checkHash(archivePath)
+	//err = checkHash(context.Background(), archivePath)
 	//if err != nil {
 	//	return fmt.Errorf("ошибка при проверке хеша архива: %v", err)
 	//}
@@ -65,7 +177,7 @@ func TryUnzipGame(dir, updaterPath string) error {
 
 	err = unzipWithProgress(archivePath, dir)
 	if err != nil {
-		return fmt.Errorf("ошибка при распаковке архива: %v", err)
+		return newError("err.install.extract", err)
 	}
 	return nil
 }
@@ -73,11 +185,11 @@ func TryUnzipGame(dir, updaterPath string) error {
 func downloadZip(archiveFile *os.File) error {
 	resp, err := http.Get(GetArchiveURL())
 	if err != nil {
-		return fmt.Errorf("ошибка при загрузке архива: %v", err)
+		return newError("err.install.download", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("сервер вернул статус %d", resp.StatusCode)
+		return newError("err.server_status", resp.StatusCode)
 	}
 
 	total := resp.ContentLength
@@ -85,28 +197,29 @@ func downloadZip(archiveFile *os.File) error {
 		total = 1
 	}
 
+	body := limitBandwidth(context.Background(), resp.Body)
 	buf := make([]byte, 32*1024)
 	downloaded := 0.0
-	ShowStyledMessage(Info, "Загрузка архива игры...")
+	ShowStyledMessage(Info, T("install.downloading_archive"))
 	for {
-		readBytes, err := resp.Body.Read(buf)
+		readBytes, err := body.Read(buf)
 		if readBytes > 0 {
 			_, err2 := archiveFile.Write(buf[:readBytes])
 			if err2 != nil {
 				return err2
 			}
 			downloaded += float64(readBytes)
-			ShowProgress(downloaded, float64(total), "📦 Загружаем")
+			ShowProgress(downloaded, float64(total), T("download.progress_label"))
 		}
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("ошибка при чтении данных: %v", err)
+			return newError("err.download.read", err)
 		}
 	}
 	fmt.Println()
-	ShowStyledMessage(Success, "Загрузка завершена!")
+	ShowStyledMessage(Success, T("self_update.download_done"))
 	return nil
 }
 
@@ -122,11 +235,11 @@ func unzipWithProgress(src, dir string) error {
 		totalFiles = 1
 	}
 
-	ShowStyledMessage(Info, "Распаковка архива...")
+	ShowStyledMessage(Info, T("download.unpacking"))
 	for i, file := range reader.File {
 		filePath := filepath.Join(dir, file.Name)
 
-		ShowProgress(float64(i), float64(totalFiles), "📦 Распаковываем")
+		ShowProgress(float64(i), float64(totalFiles), T("download.unpack_label"))
 
 		if file.FileInfo().IsDir() {
 			err := os.MkdirAll(filePath, os.ModePerm)
@@ -161,32 +274,82 @@ func unzipWithProgress(src, dir string) error {
 	}
 
 	fmt.Println()
-	ShowStyledMessage(Success, "Распаковка завершена!")
+	ShowStyledMessage(Success, T("download.unpacked"))
 	return nil
 }
 
-// downloadZipWithProgress загружает архив с отправкой прогресса в TUI
-func downloadZipWithProgress(archiveFile *os.File, progressChan chan<- InstallProgress) error {
-	resp, err := http.Get(GetArchiveURL())
+// downloadZipWithProgress загружает архив с отправкой прогресса в TUI.
+// Если offset больше нуля, загрузка продолжается с этой позиции, пока архив на сервере не изменился
+func downloadZipWithProgress(ctx context.Context, archiveFile *os.File, offset int64, progressChan chan<- InstallProgress) error {
+	archiveURL := GetArchiveURL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return newError("err.install.download", err)
+	}
+	meta := archiveMeta{URL: archiveURL}
+	if offset > 0 {
+		prev, err := readArchiveMeta(archiveFile.Name())
+		if err == nil && prev.URL == archiveURL && (prev.ETag != "" || prev.LastModified != "") {
+			meta = *prev
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			if prev.ETag != "" {
+				req.Header.Set("If-Range", prev.ETag)
+			} else {
+				req.Header.Set("If-Range", prev.LastModified)
+			}
+		} else {
+			offset = 0
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("ошибка при загрузке архива: %v", err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return newError("err.install.download", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("сервер вернул статус %d", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// Сервер отдает файл целиком: начинаем загрузку заново
+		offset = 0
+	default:
+		return newError("err.server_status", resp.StatusCode)
+	}
+	if offset == 0 {
+		if err := archiveFile.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := archiveFile.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
 	}
 
-	total := resp.ContentLength
-	if total <= 0 {
-		total = 1
+	total := int64(1)
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" || offset == 0 {
+		meta.ETag = etag
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" || offset == 0 {
+		meta.LastModified = lastModified
+	}
+	meta.Size = total
+	if err := writeArchiveMeta(archiveFile.Name(), meta); err != nil {
+		LogLauncher("Не удалось сохранить описание загрузки %s: %v", archiveFile.Name(), err)
 	}
 
+	body := limitBandwidth(ctx, resp.Body)
 	buf := make([]byte, 32*1024)
-	downloaded := int64(0)
+	downloaded := offset
 
 	for {
-		readBytes, err := resp.Body.Read(buf)
+		readBytes, err := body.Read(buf)
 		if readBytes > 0 {
 			_, err2 := archiveFile.Write(buf[:readBytes])
 			if err2 != nil {
@@ -203,7 +366,7 @@ func downloadZipWithProgress(archiveFile *os.File, progressChan chan<- InstallPr
 			progressChan <- InstallProgress{
 				Current: percent,
 				Total:   100,
-				Message: fmt.Sprintf("Загружено: %.1f MB / %.1f MB",
+				Message: T("download.progress",
 					float64(downloaded)/(1024*1024),
 					float64(total)/(1024*1024)),
 			}
@@ -213,15 +376,19 @@ func downloadZipWithProgress(archiveFile *os.File, progressChan chan<- InstallPr
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("ошибка при чтении данных: %v", err)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return newError("err.download.read", err)
 		}
 	}
 
 	return nil
 }
 
-// unzipWithProgressTUI распаковывает архив с отправкой прогресса в TUI
-func unzipWithProgressTUI(src, dir string, progressChan chan<- InstallProgress) error {
+// unzipWithProgressTUI распаковывает архив с отправкой прогресса в TUI.
+// Прогресс сохраняется в журнал, а уже распакованные целиком файлы при продолжении пропускаются
+func unzipWithProgressTUI(ctx context.Context, src, dir string, journal *installJournal, progressChan chan<- InstallProgress) error {
 	reader, err := zip.OpenReader(src)
 	if err != nil {
 		return err
@@ -232,9 +399,22 @@ func unzipWithProgressTUI(src, dir string, progressChan chan<- InstallProgress)
 	if totalFiles == 0 {
 		totalFiles = 1
 	}
+	journal.TotalFiles = len(reader.File)
 
 	for i, file := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if i > 0 && i%journalCheckpointEvery == 0 {
+			if err := journal.checkpoint(i); err != nil {
+				return err
+			}
+		}
+
 		filePath := filepath.Join(dir, file.Name)
+		if i < journal.ExtractedFiles && isStagedFileIntact(filePath, file) {
+			continue
+		}
 
 		// Рассчитываем прогресс (70-95%)
 		percent := int(float64(i)/float64(totalFiles)*25) + 70
@@ -245,7 +425,7 @@ func unzipWithProgressTUI(src, dir string, progressChan chan<- InstallProgress)
 		progressChan <- InstallProgress{
 			Current: percent,
 			Total:   100,
-			Message: fmt.Sprintf("Распаковка: %d/%d файлов", i+1, totalFiles),
+			Message: T("download.unpack_progress", i+1, totalFiles),
 		}
 
 		if file.FileInfo().IsDir() {
@@ -265,13 +445,23 @@ func unzipWithProgressTUI(src, dir string, progressChan chan<- InstallProgress)
 			return err
 		}
 
-		targetFile, err := os.Create(filePath)
+		// Сохраняем права из архива, чтобы исполняемые файлы остались исполняемыми
+		mode := file.Mode().Perm()
+		if mode == 0 {
+			mode = 0644
+		}
+		targetFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode|0200)
 		if err != nil {
 			fileReader.Close()
 			return err
 		}
 
-		_, err = io.Copy(targetFile, fileReader)
+		_, err = io.Copy(targetFile, &contextReader{ctx: ctx, r: fileReader})
+		if err == nil {
+			// Файл сбрасывается на диск до записи в журнал и до замены установленной игры,
+			// иначе после отключения питания вместо него могут остаться нули
+			err = targetFile.Sync()
+		}
 		targetFile.Close()
 		fileReader.Close()
 
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// UpdatePromptModel - модель TUI с предложением необязательного обновления
+type UpdatePromptModel struct {
+	width          int
+	height         int
+	kind           string
+	currentVersion string
+	newVersion     string
+	choices        []string
+	cursor         int
+	result         UpdateChoice
+}
+
+// NewUpdatePromptModel создает модель выбора "обновить сейчас / позже / пропустить версию"
+func NewUpdatePromptModel(kind, currentVersion, newVersion string) UpdatePromptModel {
+	later := T("update_prompt.later_next_start")
+	if days := activeSettings.UpdateReminderDays; days > 0 {
+		later = T("update_prompt.later_days", days)
+	}
+	return UpdatePromptModel{
+		width:          80,
+		height:         24,
+		kind:           kind,
+		currentVersion: currentVersion,
+		newVersion:     newVersion,
+		choices:        []string{T("update_prompt.now"), later, T("update_prompt.skip")},
+		result:         UpdateLater,
+	}
+}
+
+func (m UpdatePromptModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m UpdatePromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			// Закрытое окно - это "напомнить позже", а не отказ от версии
+			m.result = UpdateLater
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.choices)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			m.result = []UpdateChoice{UpdateNow, UpdateLater, UpdateSkip}[m.cursor]
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m UpdatePromptModel) View() string {
+	container := containerStyle.Width(m.width).Height(m.height)
+
+	logo := T("menu.logo")
+	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
+
+	title := T("update_prompt.game", m.newVersion, m.currentVersion)
+	if m.kind == UpdateKindLauncher {
+		title = T("update_prompt.launcher", m.newVersion, m.currentVersion)
+	}
+	notice := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00D4AA")).
+		Bold(true).
+		Render("🆕 " + title)
+	statusBox := boxStyle.Width(m.width - 10).Render(notice)
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(statusBox) + "\n\n"
+
+	menu := ""
+	for i, choice := range m.choices {
+		if m.cursor == i {
+			menu += selectedItemStyle.Width(34).Align(lipgloss.Center).Render("▶ "+choice) + "\n"
+		} else {
+			menu += menuItemStyle.Width(34).Align(lipgloss.Center).Render("  "+choice) + "\n"
+		}
+	}
+	menuContainer := boxStyle.Width(44).Render(menu)
+	content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(menuContainer)
+	footer := footerStyle.Width(m.width).Render(T("update_prompt.footer"))
+
+	contentHeight := strings.Count(content, "\n") + 3
+	emptyLines := (m.height - contentHeight) / 2
+	if emptyLines < 0 {
+		emptyLines = 0
+	}
+
+	result := strings.Repeat("\n", emptyLines) + content
+	footerPadding := m.height - strings.Count(result, "\n") - 2
+	if footerPadding > 0 {
+		result += strings.Repeat("\n", footerPadding)
+	}
+	result += footer
+
+	return container.Render(result)
+}
+
+// RunUpdatePromptTUI спрашивает, обновить ли лаунчер или игру сейчас, позже или пропустить версию
+func RunUpdatePromptTUI(kind, currentVersion, newVersion string) (UpdateChoice, error) {
+	model := NewUpdatePromptModel(kind, currentVersion, newVersion)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return UpdateLater, err
+	}
+	return finalModel.(UpdatePromptModel).result, nil
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,16 +13,18 @@ import (
 
 // UpdateModel - модель TUI для процесса обновления
 type UpdateModel struct {
-	width        int
-	height       int
-	state        InstallState
-	progress     InstallProgress
-	errorMsg     string
-	gameDirPath  string
-	launcherPath string
-	completed    bool
-	spinner      int
-	tickCount    int
+	width         int
+	height        int
+	state         InstallState
+	progress      InstallProgress
+	errorMsg      string
+	gameDirPath   string
+	launcherPath  string
+	completed     bool
+	spinner       int
+	tickCount     int
+	cancel        context.CancelFunc // Отменяет фоновое обновление
+	confirmCancel bool               // Показывается запрос подтверждения отмены
 }
 
 // NewUpdateModel создает новую модель обновления
@@ -31,7 +35,7 @@ func NewUpdateModel(gameDirPath, launcherPath string) UpdateModel {
 		state:        StatePreparation,
 		gameDirPath:  gameDirPath,
 		launcherPath: launcherPath,
-		progress:     InstallProgress{Current: 0, Total: 100, Message: "Подготовка к обновлению..."},
+		progress:     InstallProgress{Current: 0, Total: 100, Message: T("update.preparing")},
 	}
 }
 
@@ -52,7 +56,7 @@ func (m UpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case TickMsg:
 		m.tickCount++
 		m.spinner = (m.spinner + 1) % len(spinnerFrames)
-		if m.state != StateCompleted && m.state != StateError {
+		if isOperationActive(m.state) || m.state == StateCancelling {
 			return m, m.tickCmd()
 		}
 		return m, nil
@@ -69,15 +73,42 @@ func (m UpdateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case InstallErrorMsg:
 		m.state = StateError
 		m.errorMsg = string(msg)
+		m.confirmCancel = false
 		return m, nil
 
 	case InstallCompleteMsg:
 		m.state = StateCompleted
 		m.completed = true
+		m.confirmCancel = false
+		return m, nil
+
+	case InstallCancelledMsg:
+		m.state = StateCancelled
+		m.confirmCancel = false
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.state == StateCompleted || m.state == StateError {
+		if isOperationActive(m.state) {
+			key := msg.String()
+			if m.confirmCancel {
+				if isConfirmKey(key) {
+					m.confirmCancel = false
+					m.state = StateCancelling
+					if m.cancel != nil {
+						m.cancel()
+					}
+				} else if isRejectKey(key) {
+					m.confirmCancel = false
+				}
+				return m, nil
+			}
+			switch key {
+			case "ctrl+c", "q", "esc":
+				m.confirmCancel = true
+			}
+			return m, nil
+		}
+		if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
 			switch msg.String() {
 			case "enter", " ":
 				return m, tea.Quit
@@ -94,34 +125,42 @@ func (m UpdateModel) View() string {
 	container := containerStyle.Width(m.width).Height(m.height)
 
 	// Логотип
-	logo := `🚢 ОБНОВЛЕНИЕ СУБМАРИНЫ 🚢`
+	logo := T("update.logo")
 	content := logoStyle.Width(m.width).Render(logo) + "\n\n"
 
 	// Статус в зависимости от состояния
 	switch m.state {
 	case StatePreparation:
-		statusMsg := fmt.Sprintf("%s Подготовка к обновлению...", spinnerFrames[m.spinner])
+		statusMsg := T("update.state_preparing", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateDownloading:
-		statusMsg := fmt.Sprintf("%s Загрузка обновления...", spinnerFrames[m.spinner])
+		statusMsg := T("update.state_downloading", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateExtracting:
-		statusMsg := fmt.Sprintf("%s Установка обновления...", spinnerFrames[m.spinner])
+		statusMsg := T("update.state_extracting", spinnerFrames[m.spinner])
 		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
 
 	case StateCompleted:
-		content += installCompleteStyle.Width(m.width).Render("✅ Обновление завершено успешно!") + "\n\n"
-		content += installStatusStyle.Width(m.width).Render("Игра будет запущена автоматически...") + "\n\n"
+		content += installCompleteStyle.Width(m.width).Render(T("update.completed")) + "\n\n"
+		content += installStatusStyle.Width(m.width).Render(T("update.autostart")) + "\n\n"
 
 	case StateError:
-		content += installErrorStyle.Width(m.width).Render("❌ Ошибка обновления") + "\n"
+		content += installErrorStyle.Width(m.width).Render(T("update.error")) + "\n"
 		content += installErrorStyle.Width(m.width).Render(m.errorMsg) + "\n\n"
+
+	case StateCancelling:
+		statusMsg := T("update.cancelling", spinnerFrames[m.spinner])
+		content += installStatusStyle.Width(m.width).Render(statusMsg) + "\n\n"
+
+	case StateCancelled:
+		content += installErrorStyle.Width(m.width).Render(T("update.cancelled")) + "\n"
+		content += installStatusStyle.Width(m.width).Render(T("update.cancelled_hint")) + "\n\n"
 	}
 
 	// Прогресс бар
-	if m.state != StateError {
+	if m.state != StateError && m.state != StateCancelled {
 		progressBar := m.renderProgressBar()
 		content += lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(progressBar) + "\n\n"
 
@@ -129,9 +168,14 @@ func (m UpdateModel) View() string {
 		content += installStatusStyle.Width(m.width).Render(m.progress.Message) + "\n\n"
 	}
 
+	// Запрос подтверждения отмены
+	if m.confirmCancel {
+		content += renderCancelConfirm(m.width, T("update.confirm_cancel")) + "\n\n"
+	}
+
 	// Инструкции
-	if m.state == StateCompleted || m.state == StateError {
-		footer := footerStyle.Width(m.width).Render("Нажмите Enter для продолжения")
+	if m.state == StateCompleted || m.state == StateError || m.state == StateCancelled {
+		footer := footerStyle.Width(m.width).Render(T("ui.press_enter_to_continue"))
 		contentHeight := strings.Count(content, "\n") + 3
 		emptyLines := (m.height - contentHeight) / 2
 		if emptyLines < 0 {
@@ -155,6 +199,9 @@ func (m UpdateModel) View() string {
 	}
 
 	result := strings.Repeat("\n", emptyLines) + content
+	if isOperationActive(m.state) && !m.confirmCancel {
+		result += footerStyle.Width(m.width).Render(T("ui.footer_cancel"))
+	}
 	return container.Render(result)
 }
 
@@ -185,7 +232,7 @@ func (m UpdateModel) startUpdate() tea.Cmd {
 		go func() {
 			time.Sleep(time.Second * 1)
 		}()
-		return InstallProgressMsg{Current: 10, Total: 100, Message: "Очистка старых файлов..."}
+		return InstallProgressMsg{Current: 10, Total: 100, Message: T("update.cleaning")}
 	}
 }
 
@@ -201,9 +248,17 @@ func (m UpdateModel) GetError() string {
 	return m.errorMsg
 }
 
+func (m UpdateModel) IsCancelled() bool {
+	return m.state == StateCancelled
+}
+
 // RunUpdateTUI запускает процесс обновления в TUI режиме
-func RunUpdateTUI(gameDirPath, launcherPath string) error {
+func RunUpdateTUI(gameDirPath, launcherPath string, manifest *ManifestDto) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	model := NewUpdateModel(gameDirPath, launcherPath)
+	model.cancel = cancel
 
 	// Создаем каналы для обновления прогресса
 	progressChan := make(chan InstallProgress, 100)
@@ -220,13 +275,13 @@ func RunUpdateTUI(gameDirPath, launcherPath string) error {
 		defer close(completeChan)
 
 		// Обновление игры
-		progressChan <- InstallProgress{Current: 10, Total: 100, Message: "Начало обновления..."}
-		if err := installGameWithProgress(gameDirPath, launcherPath, progressChan); err != nil {
+		progressChan <- InstallProgress{Current: 10, Total: 100, Message: T("update.starting")}
+		if err := installGameWithProgress(ctx, gameDirPath, launcherPath, "update", manifest, progressChan); err != nil {
 			errorChan <- err
 			return
 		}
 
-		progressChan <- InstallProgress{Current: 100, Total: 100, Message: "Обновление завершено!"}
+		progressChan <- InstallProgress{Current: 100, Total: 100, Message: T("update.done")}
 		completeChan <- true
 	}()
 
@@ -245,7 +300,9 @@ func RunUpdateTUI(gameDirPath, launcherPath string) error {
 					errorChan = nil
 					continue
 				}
-				if err != nil {
+				if errors.Is(err, context.Canceled) {
+					p.Send(InstallCancelledMsg{})
+				} else if err != nil {
 					p.Send(InstallErrorMsg(err.Error()))
 				}
 			case _, ok := <-completeChan:
@@ -268,6 +325,9 @@ func RunUpdateTUI(gameDirPath, launcherPath string) error {
 	}
 
 	updateModel := finalModel.(UpdateModel)
+	if updateModel.IsCancelled() {
+		return context.Canceled
+	}
 	if updateModel.HasError() {
 		return fmt.Errorf("update failed: %s", updateModel.GetError())
 	}
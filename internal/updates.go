@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UpdatesFileName - файл с отложенными и пропущенными обновлениями рядом с файлом настроек
+const UpdatesFileName = "updates.yaml"
+
+// Что обновляется
+const (
+	UpdateKindLauncher = "launcher"
+	UpdateKindGame     = "game"
+)
+
+// UpdateChoice - ответ пользователя на предложение необязательного обновления
+type UpdateChoice int
+
+const (
+	UpdateNow   UpdateChoice = iota // Обновить сейчас
+	UpdateLater                     // Напомнить через update_reminder_days дней
+	UpdateSkip                      // Не предлагать эту версию
+)
+
+// updateDeferral - отложенное или пропущенное обновление
+type updateDeferral struct {
+	Version     string    `yaml:"version"`
+	Skipped     bool      `yaml:"skipped,omitempty"`
+	RemindAfter time.Time `yaml:"remind_after,omitempty"`
+}
+
+// sessionUpdateChoices - ответы, данные с момента запуска лаунчера: повторно в меню
+// обновление не предлагается, даже если напоминание не отложено на дни
+var sessionUpdateChoices = map[string]struct {
+	version string
+	choice  UpdateChoice
+}{}
+
+// isBelowMinimum проверяет, что версия ниже минимальной из манифеста. Версии, которые
+// не удалось сравнить, обязательным обновлением не считаются
+func isBelowMinimum(version, minimum string) bool {
+	if minimum == "" {
+		return false
+	}
+	below, err := IsVersionNewer(version, minimum)
+	if err != nil {
+		LogLauncher("Обновления: не удалось сравнить версию %s с минимальной %s: %v", version, minimum, err)
+		return false
+	}
+	return below
+}
+
+// IsLauncherUpdateMandatory проверяет, что версия лаунчера ниже min_launcher
+// и без обновления играть нельзя
+func IsLauncherUpdateMandatory(manifest *ManifestDto) bool {
+	return manifest != nil && isBelowMinimum(LauncherVersion, manifest.Version.MinLauncher)
+}
+
+// IsGameUpdateMandatory проверяет, что установленная версия игры ниже min_game
+func IsGameUpdateMandatory(localVersion string, manifest *ManifestDto) bool {
+	return manifest != nil && isBelowMinimum(localVersion, manifest.Version.MinGame)
+}
+
+// getUpdatesStatePath возвращает путь к файлу отложенных обновлений
+func getUpdatesStatePath() (string, error) {
+	configDir, err := getConfigDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, UpdatesFileName), nil
+}
+
+// readUpdatesState читает отложенные обновления. Если файла нет или он поврежден,
+// все обновления предлагаются заново
+func readUpdatesState() map[string]updateDeferral {
+	state := make(map[string]updateDeferral)
+	path, err := getUpdatesStatePath()
+	if err != nil {
+		return state
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			LogLauncher("Обновления: не удалось прочитать %s: %v", path, err)
+		}
+		return state
+	}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		LogLauncher("Обновления: не удалось разобрать %s: %v", path, err)
+		return make(map[string]updateDeferral)
+	}
+	return state
+}
+
+// writeUpdatesState атомарно записывает отложенные обновления
+func writeUpdatesState(state map[string]updateDeferral) error {
+	path, err := getUpdatesStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return newError("err.updates.write", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return newError("err.updates.write", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return newError("err.updates.write", err)
+	}
+	return nil
+}
+
+// isUpdateDeferred проверяет, что обновление до version пропущено или напоминание еще не наступило
+func isUpdateDeferred(kind, version string) bool {
+	deferral, ok := readUpdatesState()[kind]
+	if !ok || deferral.Version != version {
+		// Вышла другая версия: ее предлагаем заново
+		return false
+	}
+	return deferral.Skipped || time.Now().Before(deferral.RemindAfter)
+}
+
+// rememberUpdateChoice запоминает ответ на предложение обновления
+func rememberUpdateChoice(kind, version string, choice UpdateChoice) error {
+	sessionUpdateChoices[kind] = struct {
+		version string
+		choice  UpdateChoice
+	}{version, choice}
+
+	state := readUpdatesState()
+	switch choice {
+	case UpdateNow:
+		delete(state, kind)
+	case UpdateLater:
+		days := activeSettings.UpdateReminderDays
+		state[kind] = updateDeferral{Version: version, RemindAfter: time.Now().UTC().AddDate(0, 0, days)}
+	case UpdateSkip:
+		state[kind] = updateDeferral{Version: version, Skipped: true}
+	}
+	return writeUpdatesState(state)
+}
+
+// OfferUpdate предлагает необязательное обновление с выбором "сейчас / позже / пропустить"
+// и возвращает true, если обновить нужно сейчас. Отложенное или пропущенное обновление
+// не предлагается, пока не выйдет другая версия или не наступит напоминание
+func OfferUpdate(kind, currentVersion, newVersion string) (bool, error) {
+	if answer, ok := sessionUpdateChoices[kind]; ok && answer.version == newVersion {
+		return answer.choice == UpdateNow, nil
+	}
+	if isUpdateDeferred(kind, newVersion) {
+		return false, nil
+	}
+
+	choice, err := RunUpdatePromptTUI(kind, currentVersion, newVersion)
+	if err != nil {
+		return false, err
+	}
+	if err := rememberUpdateChoice(kind, newVersion, choice); err != nil {
+		ShowStyledMessage(Warn, T("updates.remember_failed", err))
+	}
+	return choice == UpdateNow, nil
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+// useTempConfigDir направляет папку настроек во временную папку теста
+func useTempConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestIsBelowMinimum(t *testing.T) {
+	tests := []struct {
+		version, minimum string
+		want             bool
+	}{
+		{version: "0.1.7", minimum: "", want: false},
+		{version: "0.1.7", minimum: "0.1.8", want: true},
+		{version: "0.1.8", minimum: "0.1.8", want: false},
+		{version: "0.1.9", minimum: "0.1.8", want: false},
+		{version: "0.1.8-beta", minimum: "0.1.8", want: true},
+		{version: "0.1.8", minimum: "0.1.8-alpha", want: false},
+		{version: "dev", minimum: "0.1.8", want: false},
+		{version: "0.1.7", minimum: "latest", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+" min "+tt.minimum, func(t *testing.T) {
+			if got := isBelowMinimum(tt.version, tt.minimum); got != tt.want {
+				t.Errorf("isBelowMinimum(%q, %q) = %v, want %v", tt.version, tt.minimum, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUpdateDeferred(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name     string
+		deferral *updateDeferral
+		version  string
+		want     bool
+	}{
+		{name: "ничего не отложено", deferral: nil, version: "0.1.8", want: false},
+		{name: "напоминание не наступило", deferral: &updateDeferral{Version: "0.1.8", RemindAfter: now.Add(time.Hour)}, version: "0.1.8", want: true},
+		{name: "напоминание наступило", deferral: &updateDeferral{Version: "0.1.8", RemindAfter: now.Add(-time.Minute)}, version: "0.1.8", want: false},
+		{name: "версия пропущена", deferral: &updateDeferral{Version: "0.1.8", Skipped: true}, version: "0.1.8", want: true},
+		{name: "пропущена прежняя версия", deferral: &updateDeferral{Version: "0.1.8", Skipped: true}, version: "0.1.9", want: false},
+		{name: "отложена прежняя версия", deferral: &updateDeferral{Version: "0.1.8", RemindAfter: now.Add(time.Hour)}, version: "0.1.9", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			state := map[string]updateDeferral{}
+			if tt.deferral != nil {
+				state[UpdateKindGame] = *tt.deferral
+			}
+			if err := writeUpdatesState(state); err != nil {
+				t.Fatal(err)
+			}
+			if got := isUpdateDeferred(UpdateKindGame, tt.version); got != tt.want {
+				t.Errorf("isUpdateDeferred(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+			// Отложенное обновление игры не откладывает обновление лаунчера
+			if isUpdateDeferred(UpdateKindLauncher, tt.version) {
+				t.Error("launcher update deferred by a game deferral")
+			}
+		})
+	}
+}
+
+func TestRememberUpdateChoice(t *testing.T) {
+	useTempConfigDir(t)
+	savedDays := activeSettings.UpdateReminderDays
+	activeSettings.UpdateReminderDays = 3
+	t.Cleanup(func() {
+		activeSettings.UpdateReminderDays = savedDays
+		delete(sessionUpdateChoices, UpdateKindGame)
+	})
+
+	if err := rememberUpdateChoice(UpdateKindGame, "0.1.8", UpdateLater); err != nil {
+		t.Fatal(err)
+	}
+	deferral := readUpdatesState()[UpdateKindGame]
+	wantRemind := time.Now().UTC().AddDate(0, 0, 3)
+	if diff := deferral.RemindAfter.Sub(wantRemind); diff < -time.Minute || diff > time.Minute {
+		t.Errorf("RemindAfter = %v, want about %v", deferral.RemindAfter, wantRemind)
+	}
+	if !isUpdateDeferred(UpdateKindGame, "0.1.8") {
+		t.Error("update postponed with UpdateLater is not deferred")
+	}
+
+	if err := rememberUpdateChoice(UpdateKindGame, "0.1.8", UpdateNow); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := readUpdatesState()[UpdateKindGame]; ok {
+		t.Error("UpdateNow should clear the deferral")
+	}
+}
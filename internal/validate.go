@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrInvalidInstall - файлы игры не прошли проверку
+var ErrInvalidInstall = newError("err.validate.failed")
+
+// ValidateGameInstall проверяет файлы игры в указанной папке: исполняемый файл для текущей платформы,
+// файл версии и обязательные файлы из манифеста. Возвращает ошибку со списком всех найденных проблем
+func ValidateGameInstall(dir string, manifest *ManifestDto) error {
+	var problems []string
+
+	if problem := checkGameExecutable(filepath.Join(dir, GetExecutableForPlatform())); problem != "" {
+		problems = append(problems, problem)
+	}
+
+	versionPath := filepath.Join(dir, GameVersionFileName)
+	localVersion, err := GetGameLocalVersion(versionPath)
+	if err != nil {
+		problems = append(problems, T("validate.version_unreadable", GameVersionFileName, err))
+	} else if localVersion == "" {
+		problems = append(problems, T("validate.version_empty", GameVersionFileName))
+	} else if manifest != nil && manifest.Version.Game != "" && localVersion != manifest.Version.Game {
+		problems = append(problems, T("validate.version_mismatch", localVersion, manifest.Version.Game))
+	}
+
+	if manifest != nil {
+		for _, required := range manifest.RequiredFiles {
+			path := filepath.Join(dir, filepath.FromSlash(required))
+			if !isRequiredFilePathSafe(dir, required) {
+				// Манифест не может заставить проверять файлы вне папки игры
+				problems = append(problems, T("validate.required_unsafe", required))
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				problems = append(problems, T("validate.required_missing", required))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return newError("err.validate.problems", ErrInvalidInstall, strings.Join(problems, "\n- "))
+	}
+	return nil
+}
+
+// isRequiredFilePathSafe проверяет, что путь из required_files относительный и не выходит
+// из папки игры через ".."
+func isRequiredFilePathSafe(dir, required string) bool {
+	native := filepath.FromSlash(required)
+	if strings.HasPrefix(required, "/") || filepath.IsAbs(native) || filepath.VolumeName(native) != "" {
+		return false
+	}
+	return isSubPath(dir, filepath.Join(dir, native))
+}
+
+// checkGameExecutable проверяет исполняемый файл игры. На Unix-системах права на выполнение
+// выставляются так же, как при запуске игры. Возвращает описание проблемы или пустую строку
+func checkGameExecutable(gamePath string) string {
+	info, err := os.Stat(gamePath)
+	if err != nil {
+		return T("validate.executable_missing", GetExecutableForPlatform())
+	}
+	if !info.Mode().IsRegular() {
+		return T("validate.executable_not_file", GetExecutableForPlatform())
+	}
+	if info.Size() == 0 {
+		return T("validate.executable_empty", GetExecutableForPlatform())
+	}
+
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+		if info.Mode().Perm()&0111 == 0 {
+			if err := os.Chmod(gamePath, 0755); err != nil {
+				return T("validate.chmod_failed", err)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsRequiredFilePathSafe(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "game")
+	tests := []struct {
+		required string
+		want     bool
+	}{
+		{required: "data/level.pck", want: true},
+		{required: "tts", want: true},
+		{required: "data/../tts", want: true},
+		{required: "../secret", want: false},
+		{required: "data/../../secret", want: false},
+		{required: "..", want: false},
+		{required: ".", want: false},
+		{required: "/etc/passwd", want: false},
+		{required: filepath.Join(dir, "tts"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.required, func(t *testing.T) {
+			if got := isRequiredFilePathSafe(dir, tt.required); got != tt.want {
+				t.Errorf("isRequiredFilePathSafe(%q) = %v, want %v", tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGameInstallRejectsUnsafeRequiredFiles(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "game")
+	files := map[string]string{
+		filepath.Join(dir, GetExecutableForPlatform()): "game",
+		filepath.Join(dir, GameVersionFileName):        "version: 0.1.8\n",
+		filepath.Join(root, "secret"):                  "outside",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest := &ManifestDto{}
+	manifest.Version.Game = "0.1.8"
+	if err := ValidateGameInstall(dir, manifest); err != nil {
+		t.Fatalf("ValidateGameInstall() without required files error = %v", err)
+	}
+
+	// Файлы существуют, но лежат вне папки игры
+	for _, required := range []string{"../secret", filepath.Join(root, "secret")} {
+		manifest.RequiredFiles = []string{required}
+		err := ValidateGameInstall(dir, manifest)
+		if !errors.Is(err, ErrInvalidInstall) {
+			t.Fatalf("ValidateGameInstall(required %q) error = %v, want ErrInvalidInstall", required, err)
+		}
+		if !strings.Contains(err.Error(), T("validate.required_unsafe", required)) {
+			t.Errorf("ValidateGameInstall(required %q) error = %v, want the unsafe path problem", required, err)
+		}
+	}
+}
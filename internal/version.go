@@ -1,12 +1,12 @@
 package internal
 
 import (
-	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -26,14 +26,14 @@ func parseVersion(version string) ([]int, string, error) {
 
 	parts := strings.Split(versionPart, ".")
 	if len(parts) == 0 || len(parts) > 3 {
-		return nil, "", fmt.Errorf("неверный формат версии: %s", version)
+		return nil, "", newError("err.version.format", version)
 	}
 
 	var nums []int
 	for _, part := range parts {
 		num, err := strconv.Atoi(part)
 		if err != nil {
-			return nil, "", fmt.Errorf("неверный формат версии: %s", version)
+			return nil, "", newError("err.version.format", version)
 		}
 		nums = append(nums, num)
 	}
@@ -108,6 +108,8 @@ func IsVersionNewer(localVersion, remoteVersion string) (bool, error) {
 // CustomTime представляет время, которое можно разобрать из более короткого формата
 type CustomTime struct {
 	time.Time
+	zoneless bool   // В манифесте не указано смещение: время уточняется по timezone манифеста
+	raw      string // Исходная запись для сообщений об ошибках
 }
 
 // UnmarshalYAML реализует пользовательскую разборку для форматов времени
@@ -116,61 +118,190 @@ func (ct *CustomTime) UnmarshalYAML(value *yaml.Node) error {
 	if err := value.Decode(&timeStr); err != nil {
 		return err
 	}
+	ct.raw = timeStr
 
-	// Пробуем разобрать с разными форматами
-	formats := []string{
-		"2006-01-02T15:04:05Z07:00", // Полный RFC3339
-		"2006-01-02T15:04:05Z",      // RFC3339 без смещения часового пояса
-		"2006-01-02T15:04:05",       // Без часового пояса
-		"2006-01-02T15:04",          // Без секунд и часового пояса
+	// Время со смещением однозначно
+	zonedFormats := []string{
+		"2006-01-02T15:04:05Z07:00", // Полный RFC3339, Z - это UTC
+		"2006-01-02T15:04Z07:00",    // Без секунд
+	}
+	for _, format := range zonedFormats {
+		if t, err := time.Parse(format, timeStr); err == nil {
+			ct.Time = t
+			return nil
+		}
 	}
 
-	for _, format := range formats {
+	// Время без смещения пока считается UTC, его уточняет resolve
+	zonelessFormats := []string{
+		"2006-01-02T15:04:05", // Без часового пояса
+		"2006-01-02T15:04",    // Без секунд и часового пояса
+	}
+	for _, format := range zonelessFormats {
 		if t, err := time.Parse(format, timeStr); err == nil {
 			ct.Time = t
+			ct.zoneless = true
 			return nil
 		}
 	}
 
-	return fmt.Errorf("не удалось разобрать время: %s", timeStr)
+	return newError("err.time.parse", timeStr)
 }
 
 // ManifestDto представляет новый формат версий
 type ManifestDto struct {
 	Version struct {
-		Game     string `yaml:"game"`
-		Launcher string `yaml:"launcher"`
-	} `yaml:"version"`
-	Shutdown *CustomTime `yaml:"shutdown,omitempty"`
-	Message  *struct {
-		Text      string `yaml:"text"`
-		Important bool   `yaml:"important"`
-	} `yaml:"message,omitempty"`
+		Game     string `yaml:"game" json:"game"`
+		Launcher string `yaml:"launcher" json:"launcher"`
+		// MinGame и MinLauncher - минимальные версии, с которыми работает сервер.
+		// Обновление с более старой версии обязательно, остальные можно отложить
+		MinGame     string `yaml:"min_game,omitempty" json:"min_game,omitempty"`
+		MinLauncher string `yaml:"min_launcher,omitempty" json:"min_launcher,omitempty"`
+	} `yaml:"version" json:"version"`
+	// BlockedLaunchers - версии лаунчера с критическими ошибками и что с ними делать
+	BlockedLaunchers []BlockedLauncher `yaml:"blocked_launchers,omitempty" json:"blocked_launchers,omitempty"`
+	// Rollout - новые версии, которые пока получает только часть установок
+	Rollout *ManifestRollouts `yaml:"rollout,omitempty" json:"rollout,omitempty"`
+	// TimeZone - часовой пояс IANA (например, Europe/Moscow) для времени без смещения.
+	// Без него такое время считается UTC
+	TimeZone string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	// StrictTime запрещает неоднозначное время: без смещения и без timezone, а также
+	// попадающее на перевод часов
+	StrictTime bool `yaml:"strict_time,omitempty" json:"strict_time,omitempty"`
+	// ServerTime - время сервера на момент формирования манифеста, точнее заголовка Date.
+	// Имеет смысл, только если манифест генерируется при каждом запросе
+	ServerTime *CustomTime `yaml:"server_time,omitempty" json:"server_time,omitempty"`
+	// Shutdown - начало обслуживания без времени окончания, формат ранних манифестов
+	Shutdown *CustomTime `yaml:"shutdown,omitempty" json:"shutdown,omitempty"`
+	// Maintenance - окна технического обслуживания с началом, окончанием и причиной
+	Maintenance []MaintenanceWindow `yaml:"maintenance,omitempty" json:"maintenance,omitempty"`
+	// ShutdownMessage заменяет стандартный текст о техническом обслуживании.
+	// Вместо {time} подставляется время начала обслуживания, вместо {end} - время окончания
+	ShutdownMessage LocalizedText `yaml:"shutdown_message,omitempty" json:"shutdown_message,omitempty"`
+	Message         *struct {
+		Text      LocalizedText `yaml:"text" json:"text"`
+		Important bool          `yaml:"important" json:"important"`
+	} `yaml:"message,omitempty" json:"message,omitempty"`
+	// News - новости для ленты в главном меню
+	News []NewsItem `yaml:"news,omitempty" json:"news,omitempty"`
+	// Releases - описания версий игры для экрана перед обновлением
+	Releases []Release `yaml:"releases,omitempty" json:"releases,omitempty"`
+	// RequiredFiles - файлы (пути относительно папки игры), без которых установка считается неполной
+	RequiredFiles []string `yaml:"required_files,omitempty" json:"required_files,omitempty"`
 }
 
+// manifestCache - последний полученный манифест с ETag и Last-Modified для условных запросов
+type manifestCache struct {
+	url          string
+	etag         string
+	lastModified string
+	manifest     *ManifestDto
+	body         string
+}
+
+var (
+	lastManifest   manifestCache
+	lastManifestMu sync.Mutex
+)
+
 // GetRemoteManifest получает информацию о версиях с сервера
 func GetRemoteManifest() (*ManifestDto, error) {
-	resp, err := http.Get(RemoteManifestURL)
+	manifest, _, err := RefreshManifest()
+	return manifest, err
+}
+
+// RefreshManifest запрашивает манифест с If-None-Match и If-Modified-Since. Если сервер
+// ответил 304, возвращается ранее полученный манифест и changed = false. Полученный
+// манифест сохраняется на диск, чтобы следующий запуск тоже начинался с условного запроса
+func RefreshManifest() (manifest *ManifestDto, changed bool, err error) {
+	lastManifestMu.Lock()
+	defer lastManifestMu.Unlock()
+
+	// Манифест другого канала не годится для условного запроса
+	url := RemoteManifestURL
+	if lastManifest.url != url {
+		lastManifest = manifestCache{url: url}
+		if cache, manifest, err := readManifestCache(url); err == nil {
+			lastManifest.etag, lastManifest.lastModified = cache.ETag, cache.LastModified
+			lastManifest.manifest, lastManifest.body = manifest, cache.Body
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, newError("err.manifest.request", err)
+	}
+	if lastManifest.manifest != nil {
+		if lastManifest.etag != "" {
+			req.Header.Set("If-None-Match", lastManifest.etag)
+		}
+		if lastManifest.lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastManifest.lastModified)
+		}
+	}
+
+	sent := time.Now()
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при запросе версии: %v", err)
+		return nil, false, newError("err.manifest.request", err)
 	}
 	defer resp.Body.Close()
+	received := time.Now()
 
+	if resp.StatusCode == http.StatusNotModified && lastManifest.manifest != nil {
+		// server_time сохраненного манифеста устарел, поэтому часы сверяются только по Date
+		updateClockOffset(resp, nil, sent, received)
+		lastManifest.save()
+		return lastManifest.manifest.withRollouts(), false, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("сервер вернул статус %d", resp.StatusCode)
+		return nil, false, newError("err.server_status", resp.StatusCode)
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при чтении ответа с сервера: %v", err)
+		return nil, false, newError("err.manifest.read", err)
+	}
+	manifest, err = parseManifest(data)
+	if err != nil {
+		return nil, false, err
 	}
+	updateClockOffset(resp, manifest, sent, received)
+	lastManifest.etag = resp.Header.Get("ETag")
+	lastManifest.lastModified = resp.Header.Get("Last-Modified")
+	lastManifest.manifest = manifest
+	// Без BOM тело манифеста сохраняется в кэше читаемым блоком YAML
+	lastManifest.body = strings.TrimPrefix(string(data), "\ufeff")
+	lastManifest.save()
+	return manifest.withRollouts(), true, nil
+}
 
-	var manifest ManifestDto
-	err = yaml.Unmarshal(data, &manifest)
+// save записывает манифест в кэш на диске с текущим временем получения.
+// Ошибка записи не мешает работе: следующий запуск просто загрузит манифест целиком
+func (c *manifestCache) save() {
+	err := writeManifestCache(&manifestCacheFile{
+		URL:          c.url,
+		ETag:         c.etag,
+		LastModified: c.lastModified,
+		FetchedAt:    time.Now().UTC(),
+		Body:         c.body,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при разборе YAML: %v", err)
+		LogLauncher("Манифест: не удалось сохранить кэш: %v", err)
 	}
+}
 
+// parseManifest разбирает манифест из ответа сервера или кэша
+func parseManifest(data []byte) (*ManifestDto, error) {
+	var manifest ManifestDto
+	err := yaml.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, newError("err.manifest.parse", err)
+	}
+	if err := manifest.resolveTimes(); err != nil {
+		return nil, newError("err.manifest.times", err)
+	}
+	manifest.Maintenance = validMaintenanceWindows(manifest.Maintenance)
 	return &manifest, nil
 }
 
@@ -178,7 +309,7 @@ func GetRemoteManifest() (*ManifestDto, error) {
 func GetGameLocalVersion(versionFilePath string) (string, error) {
 	data, err := os.ReadFile(versionFilePath)
 	if err != nil {
-		return "", fmt.Errorf("ошибка при чтении файла версии: %v", err)
+		return "", newError("err.version_file.read", err)
 	}
 
 	// GameVersionDto представляет формат версии игры
@@ -189,7 +320,7 @@ func GetGameLocalVersion(versionFilePath string) (string, error) {
 	var gameVersion *GameVersionDto
 	err = yaml.Unmarshal(data, &gameVersion)
 	if err != nil {
-		return "", fmt.Errorf("ошибка при разборе YAML версии игры: %v", err)
+		return "", newError("err.version_file.parse", err)
 	}
 
 	return gameVersion.Version, nil
@@ -210,28 +341,29 @@ func NeedsGameUpdate(localVersion, remoteVersion *ManifestDto) bool {
 	return localVersion.Version.Game != remoteVersion.Version.Game
 }
 
-// GetMaintenanceMessage возвращает сообщение о техническом обслуживании
+// GetMaintenanceMessage возвращает сообщение о текущем или ближайшем техническом
+// обслуживании с обратным отсчетом
 func GetMaintenanceMessage(versionInfo *ManifestDto) (string, string) {
-	if versionInfo.Shutdown == nil {
-		return "", ""
-	}
-
-	now := time.Now().UTC()
-	shutdownTime := versionInfo.Shutdown.Time
+	now := ServerNow()
 
-	if now.Before(shutdownTime) {
-		// Техническое обслуживание еще не началось
-		return fmt.Sprintf("Внимание! В %s начнется техническое обслуживание, в это время игра будет недоступна",
-			shutdownTime.Format("2006-01-02 15:04")), Warn
-	} else {
+	if window := CurrentMaintenance(versionInfo, now); window != nil {
 		// Техническое обслуживание уже идет
-		return "Внимание! Идет техническое обслуживание, игра недоступна", Error
+		return maintenanceText(versionInfo, window, now), Error
+	}
+	if window := NextMaintenance(versionInfo, now); window != nil {
+		// Техническое обслуживание еще не началось
+		return maintenanceText(versionInfo, window, now), Warn
 	}
+	return "", ""
 }
 
-// GetServerMessage возвращает серверное сообщение
+// GetServerMessage возвращает серверное сообщение на языке интерфейса
 func GetServerMessage(versionInfo *ManifestDto) (string, string) {
-	if versionInfo.Message == nil || versionInfo.Message.Text == "" {
+	if versionInfo.Message == nil {
+		return "", ""
+	}
+	text := versionInfo.Message.Text.String()
+	if text == "" {
 		return "", ""
 	}
 
@@ -240,15 +372,10 @@ func GetServerMessage(versionInfo *ManifestDto) (string, string) {
 		messageType = Error
 	}
 
-	return versionInfo.Message.Text, messageType
+	return text, messageType
 }
 
 // IsGameAccessible проверяет, доступна ли игра (не идет ли техническое обслуживание)
 func IsGameAccessible(versionInfo *ManifestDto) bool {
-	if versionInfo.Shutdown == nil {
-		return true
-	}
-
-	now := time.Now().UTC()
-	return now.Before(versionInfo.Shutdown.Time)
+	return CurrentMaintenance(versionInfo, ServerNow()) == nil
 }
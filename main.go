@@ -1,15 +1,36 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 	"submarine-launcher/internal"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// Без терминала, с NO_COLOR или --plain лаунчер выводит построчный текст без вопросов
+	args := internal.DetectPlainMode(os.Args[1:])
+
+	// С подкомандой лаунчер работает как неинтерактивная утилита командной строки
+	if internal.IsCLICommand(args) {
+		os.Exit(internal.RunCLI(args))
+	}
+
+	// Настройки из файла, переменных окружения и флагов
+	if err := internal.ParseLauncherFlags(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(internal.ExitOK)
+		}
+		os.Exit(internal.ExitUsage)
+	}
+	if internal.IsPlainMode() {
+		os.Exit(internal.RunPlainLauncher())
+	}
+
 	// Включаем поддержку цветов в Windows терминале
 	if err := internal.EnableWindowsColors(); err == nil {
 		// Очистка экрана для красивого отображения
@@ -18,88 +39,172 @@ func main() {
 
 	launcherPath, err := os.Executable()
 	if err != nil {
-		internal.ShowExitMessage(internal.Error, "Ошибка при получении пути к исполняемому файлу: "+err.Error())
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.executable_path_error", err))
 		return
 	}
 
-	// Проверяем обновления лаунчера в первую очередь
-	manifest, err := internal.GetRemoteManifest()
-	if err != nil {
-		internal.ShowStyledMessage(internal.Warn, "Не удалось проверить обновления лаунчера: "+err.Error())
-	} else if internal.NeedsLauncherUpdate(manifest) {
-		internal.ShowStyledMessage(internal.Info, fmt.Sprintf("Найдено обновление лаунчера: %s → %s", internal.LauncherVersion, manifest.Version.Launcher))
+	// Манифест нужен раньше всего остального: текущая версия лаунчера может быть заблокирована
+	// из-за ошибки, которая проявится дальше
+	launcherNotice := ""
+	manifest, manifestErr := internal.GetRemoteManifest()
+	if manifestErr != nil {
+		internal.ShowStyledMessage(internal.Warn, internal.T("launcher.update_check_failed", manifestErr))
+		// Без сервера меню показывает последний сохраненный манифест: новости, обслуживание, версии
+		if cached, fetchedAt, cacheErr := internal.LoadCachedManifest(); cacheErr == nil {
+			manifest = cached
+			launcherNotice = internal.T("launcher.offline_cached", fetchedAt.Local().Format("2006-01-02 15:04"))
+		}
+	}
+	if block := internal.LauncherBlockFor(manifest); block != nil {
+		if block.Action == internal.BlockActionForceUpdate {
+			updateBlockedLauncher(launcherPath, manifest, block)
+			return
+		}
+		// Об ошибке в лаунчере важнее знать, чем о работе без сети
+		launcherNotice = block.Text()
+	}
+
+	gameDirPath := internal.GetGameDirPath(launcherPath)
+
+	// Из папки только для чтения игру не установить и не обновить: предлагаем другую папку
+	if err := internal.CheckGameDirWritable(gameDirPath); err != nil {
+		if internal.IsGameDirOverridden() {
+			internal.ShowExitMessage(internal.Error, internal.T("launcher.game_dir_overridden_read_only", err))
+			return
+		}
+		newDir, ok, tuiErr := internal.RunRelocateTUI(gameDirPath, launcherPath, err)
+		if tuiErr != nil {
+			internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", tuiErr))
+			return
+		}
+		if !ok {
+			internal.ShowStyledMessage(internal.Info, internal.T("ui.goodbye"))
+			return
+		}
+		gameDirPath = newDir
+	}
+
+	if err := internal.OpenLauncherLog(internal.GetLogDirPath(gameDirPath)); err != nil {
+		internal.ShowStyledMessage(internal.Warn, internal.T("launcher.log_open_failed", err))
+	}
+	defer internal.CloseLauncherLog()
+
+	// Проверяем обновления лаунчера до работы с игрой
+	switch {
+	case manifestErr != nil:
+		// Сервер недоступен, об этом уже сообщено
+	case internal.IsLauncherUpdateMandatory(manifest) && internal.CheckLauncherDirWritable(launcherPath) != nil:
+		// Старая версия не работает с сервером, а заменить файл лаунчера нельзя
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.update_required_manual",
+			internal.LauncherVersion, manifest.Version.MinLauncher, internal.ManualUpdateHint(launcherPath)))
+		return
+	case internal.NeedsLauncherUpdate(manifest) && internal.CheckLauncherDirWritable(launcherPath) != nil:
+		// Заменить файл лаунчера нельзя: сообщаем о новой версии в меню, после предупреждения о блокировке
+		notice := internal.T("launcher.update_manual", manifest.Version.Launcher, internal.ManualUpdateHint(launcherPath))
+		if launcherNotice != "" {
+			notice = launcherNotice + "\n" + notice
+		}
+		launcherNotice = notice
+	case internal.NeedsLauncherUpdate(manifest) && launcherUpdateAccepted(manifest):
+		if internal.IsLauncherUpdateMandatory(manifest) {
+			internal.ShowStyledMessage(internal.Warn, internal.T("launcher.update_required", internal.LauncherVersion, manifest.Version.Launcher))
+		} else {
+			internal.ShowStyledMessage(internal.Info, internal.T("launcher.update_found", internal.LauncherVersion, manifest.Version.Launcher))
+		}
 
 		// Запускаем красивый TUI для обновления лаунчера
 		err = internal.RunLauncherUpdateTUI(launcherPath)
 		if err != nil {
-			internal.ShowExitMessage(internal.Error, "Ошибка при обновлении лаунчера: "+err.Error())
+			internal.ShowExitMessage(internal.Error, internal.T("launcher.update_failed", err))
 			return
 		}
 		// RunLauncherUpdateTUI завершает процесс, поэтому эта строка не выполнится
 	}
 
-	gameDirPath := internal.GetGameDirPath(launcherPath)
+	// Время обслуживания считается по часам сервера, но о сбитых часах стоит знать
+	if warning := internal.ClockSkewWarning(); warning != "" && launcherNotice == "" {
+		launcherNotice = warning
+	}
+
+	// Захватываем блокировку директории игры, чтобы с ней не работали два лаунчера одновременно
+	readOnly := false
+	lock, holder, err := internal.AcquireInstanceLock(gameDirPath)
+	if errors.Is(err, internal.ErrInstanceLocked) {
+		var lockChoice internal.LockChoice
+		lock, lockChoice, err = internal.RunInstanceLockTUI(gameDirPath, holder)
+		if err != nil {
+			internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
+			return
+		}
+		switch lockChoice {
+		case internal.LockChoiceQuit:
+			internal.ShowStyledMessage(internal.Info, internal.T("ui.goodbye"))
+			return
+		case internal.LockChoiceReadOnly:
+			readOnly = true
+		}
+	} else if err != nil {
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.lock_failed", err))
+		return
+	}
+	defer func() { lock.Release() }()
+
+	// Разбираем журнал прерванной установки и убираем остатки прерванных операций,
+	// пока с директорией никто не работает. Без блокировки временные файлы могут быть чужими
+	if !readOnly {
+		if err := internal.RecoverInstall(gameDirPath, launcherPath); err != nil {
+			internal.ShowStyledMessage(internal.Error, internal.T("launcher.recover_failed", err))
+		}
+		internal.CleanupLeftovers(launcherPath, gameDirPath)
+	}
 
 	// Основной цикл лаунчера
 	for {
-		// Проверяем наличие игры
-		localGameVersionPath := filepath.Join(gameDirPath, internal.GameVersionFileName)
-		gameDirExist := true
-		gameInstalled := true
-		needsUpdate := false
-
-		if _, err := os.Stat(gameDirPath); os.IsNotExist(err) {
-			gameDirExist = false
-			gameInstalled = false
-		} else if err != nil {
-			internal.ShowExitMessage(internal.Error, "Ошибка при проверке папки игры: "+err.Error())
+		// Проверяем наличие игры и необходимость обновления
+		state, err := internal.DetectGameState(gameDirPath, manifest)
+		if err != nil {
+			internal.ShowExitMessage(internal.Error, err.Error())
 			return
 		}
+		gameInstalled := state.Installed
+		needsUpdate := state.NeedsUpdate
 
-		if gameDirExist {
-			if _, err := os.Stat(localGameVersionPath); os.IsNotExist(err) {
-				gameInstalled = false
-			} else if err != nil {
-				internal.ShowExitMessage(internal.Error, "Ошибка при проверке версии игры: "+err.Error())
-				return
-			}
+		// Заблокированная версия лаунчера не трогает файлы игры: установленную можно только запустить
+		if internal.GameUpdatesBlocked(manifest) {
+			needsUpdate = false
 		}
 
-		// Если игра установлена, проверяем обновления
-		if gameInstalled {
-			localVersion, err := internal.GetGameLocalVersion(localGameVersionPath)
+		// Необязательное обновление игры можно отложить или пропустить, тогда игра запускается как есть
+		if needsUpdate && !readOnly && !internal.IsGameUpdateMandatory(state.LocalVersion, manifest) {
+			needsUpdate, err = internal.OfferUpdate(internal.UpdateKindGame, state.LocalVersion, manifest.Version.Game)
 			if err != nil {
-				internal.ShowExitMessage(internal.Error, "Ошибка при чтении файла с версией: "+err.Error())
+				internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
 				return
 			}
-
-			if err != nil {
-				internal.ShowStyledMessage(internal.Warn, "Не удалось проверить обновления, запускаем игру...")
-			} else {
-				// Используем семантическое сравнение версий
-				isNewer, err := internal.IsVersionNewer(localVersion, manifest.Version.Game)
-				if err != nil {
-					// Если не удалось сравнить версии семантически, используем строковое сравнение
-					internal.ShowStyledMessage(internal.Warn, "Не удалось сравнить версии семантически: "+err.Error())
-					needsUpdate = localVersion != manifest.Version.Game
-				} else {
-					needsUpdate = isNewer
-				}
-			}
 		}
 
 		// Создаем и запускаем TUI модель
-		model := internal.NewTUIModel(gameInstalled, needsUpdate, manifest)
+		model := internal.NewTUIModel(gameInstalled, needsUpdate, manifest).
+			WithManifestRefresh(gameDirPath, time.Duration(internal.CurrentSettings().ManifestRefresh)*time.Minute).
+			WithLocalVersion(state.LocalVersion)
+		if readOnly {
+			model = model.WithReadOnly(holder)
+		}
+		if launcherNotice != "" {
+			model = model.WithStatus(launcherNotice, internal.Warn)
+		}
 		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 		finalModel, err := p.Run()
 		if err != nil {
-			internal.ShowStyledMessage(internal.Error, "Ошибка интерфейса: "+err.Error())
+			internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
 			return
 		}
 
-		// Обрабатываем выбор пользователя
+		// Обрабатываем выбор пользователя. Пока меню было открыто, манифест мог обновиться
 		tuiModel := finalModel.(internal.TUIModel)
+		manifest = tuiModel.Manifest()
+		needsUpdate = tuiModel.NeedsUpdate()
 		if !tuiModel.WasSelected() {
 			return
 		}
@@ -107,11 +212,64 @@ func main() {
 
 		shouldExit := false
 
+		// В режиме только для чтения можно лишь повторно проверить блокировку
+		if readOnly {
+			if choice != 0 {
+				internal.ShowStyledMessage(internal.Info, internal.T("ui.goodbye"))
+				return
+			}
+			lock, holder, err = internal.AcquireInstanceLock(gameDirPath)
+			if err == nil {
+				readOnly = false
+			} else if !errors.Is(err, internal.ErrInstanceLocked) {
+				internal.ShowStyledMessage(internal.Warn, internal.T("launcher.lock_check_failed", err))
+			}
+			continue
+		}
+
+		// Настройки доступны в любом состоянии игры
+		if choice == 1 {
+			result, err := internal.RunSettingsTUI(gameDirPath, launcherPath, gameInstalled)
+			if err != nil {
+				internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
+				return
+			}
+			if result.MoveTo != "" {
+				// Переносим установленную игру, новая папка запоминается только после успешного переноса
+				lock, err = internal.RunMoveGameTUI(lock, gameDirPath, result.MoveTo, launcherPath)
+				if err == nil {
+					gameDirPath = result.MoveTo
+				}
+			} else if newDir := internal.GetGameDirPath(launcherPath); newDir != gameDirPath {
+				// Игра не установлена: просто переключаемся на новую папку
+				if newLock, err := internal.SwitchInstanceLock(lock, newDir); err != nil {
+					internal.ShowStyledMessage(internal.Error, internal.T("launcher.switch_dir_failed", err))
+				} else {
+					lock, gameDirPath = newLock, newDir
+				}
+			}
+			if result.Saved {
+				// Канал обновлений мог измениться: перечитываем манифест
+				if newManifest, err := internal.GetRemoteManifest(); err == nil {
+					manifest = newManifest
+				} else {
+					internal.ShowStyledMessage(internal.Warn, internal.T("launcher.manifest_failed", err))
+				}
+			}
+			continue
+		}
+
+		// Пока игра запущена, ее нельзя ни обновлять, ни запускать повторно
+		if choice == 0 && lock.IsGameRunning() {
+			internal.ShowStyledMessage(internal.Error, internal.T("launcher.game_running"))
+			continue
+		}
+
 		// Проверяем доступность игры перед выполнением действий
 		if manifest != nil && !internal.IsGameAccessible(manifest) {
 			// Если идет техническое обслуживание, блокируем запуск/обновление игры
 			if choice == 0 && (gameInstalled || needsUpdate) {
-				internal.ShowStyledMessage(internal.Error, "Игра недоступна из-за технического обслуживания")
+				internal.ShowStyledMessage(internal.Error, internal.T("launcher.maintenance_blocked"))
 				continue // Возвращаемся в меню
 			}
 		}
@@ -120,55 +278,131 @@ func main() {
 			// Игра не установлена
 			switch choice {
 			case 0: // Установить игру
+				if internal.GameUpdatesBlocked(manifest) {
+					internal.ShowStyledMessage(internal.Error, internal.LauncherBlockFor(manifest).Text())
+					continue
+				}
+
+				// Предлагаем выбрать папку установки, если она не задана переменной окружения или флагом
+				if !internal.IsGameDirOverridden() {
+					dir, ok, err := internal.RunInstallLocationTUI(gameDirPath)
+					if err != nil {
+						internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
+						return
+					}
+					if !ok {
+						continue
+					}
+					if dir != gameDirPath {
+						newLock, err := internal.SwitchInstanceLock(lock, dir)
+						if err != nil {
+							internal.ShowStyledMessage(internal.Error, internal.T("launcher.choose_dir_failed", err))
+							continue
+						}
+						lock, gameDirPath = newLock, dir
+						if err := internal.RememberGameDir(launcherPath, dir); err != nil {
+							internal.ShowStyledMessage(internal.Warn, internal.T("launcher.remember_dir_failed", err))
+						}
+						// В выбранной папке игра может быть уже установлена
+						if state, err := internal.DetectGameState(gameDirPath, manifest); err == nil && state.Installed {
+							continue
+						}
+					}
+				}
+
 				// Запускаем установку в TUI режиме
-				err = internal.RunInstallationTUI(gameDirPath, launcherPath)
+				err = internal.RunInstallationTUI(gameDirPath, launcherPath, manifest)
 				if err != nil {
 					// Показываем ошибку в TUI режиме и возвращаемся в меню
 					continue
 				}
 				// Продолжаем цикл, чтобы показать обновленное меню
 				continue
-			case 1: // Выход
+			case 2: // Выход
 				shouldExit = true
 			}
 		} else if needsUpdate {
 			// Игра установлена, но нужно обновление
 			switch choice {
 			case 0: // Обновить игру
+				// Сначала показываем, что изменилось с установленной версии
+				confirmed, err := internal.RunReleaseNotesTUI(state.LocalVersion, manifest, tuiModel.UpdateSize())
+				if err != nil {
+					internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
+					return
+				}
+				if !confirmed {
+					continue
+				}
+
 				// Запускаем обновление в TUI режиме
-				err = internal.RunUpdateTUI(gameDirPath, launcherPath)
+				err = internal.RunUpdateTUI(gameDirPath, launcherPath, manifest)
 				if err != nil {
 					// Показываем ошибку и возвращаемся в меню
 					continue
 				}
 				// После успешного обновления запускаем игру
-				err = internal.TryRunGame(gameDirPath)
+				err = internal.TryRunGame(gameDirPath, lock)
 				if err != nil {
-					internal.ShowStyledMessage(internal.Error, "Ошибка при запуске игры: "+err.Error())
+					internal.ShowStyledMessage(internal.Error, internal.T("launcher.run_failed", err))
 				}
 				// Возвращаемся в меню после завершения игры
 				continue
-			case 1: // Выход
+			case 2: // Выход
 				shouldExit = true
 			}
 		} else {
 			// Игра установлена и актуальна
 			switch choice {
 			case 0: // Запустить игру
-				err = internal.TryRunGame(gameDirPath)
+				err = internal.TryRunGame(gameDirPath, lock)
 				if err != nil {
-					internal.ShowStyledMessage(internal.Error, "Ошибка при запуске игры: "+err.Error())
+					internal.ShowStyledMessage(internal.Error, internal.T("launcher.run_failed", err))
 				}
 				// Возвращаемся в меню после завершения игры
 				continue
-			case 1: // Выход
+			case 2: // Выход
 				shouldExit = true
 			}
 		}
 
 		if shouldExit {
-			internal.ShowStyledMessage(internal.Info, "Лаунчер закрыт! 👋")
+			internal.ShowStyledMessage(internal.Info, internal.T("ui.goodbye"))
 			return
 		}
 	}
 }
+
+// updateBlockedLauncher обновляет заблокированную версию лаунчера без вопросов. Если исправленной
+// версии еще нет или файл лаунчера не заменить, лаунчер завершается с сообщением о блокировке
+func updateBlockedLauncher(launcherPath string, manifest *internal.ManifestDto, block *internal.BlockedLauncher) {
+	internal.LogLauncher("Версия лаунчера %s заблокирована манифестом: %s", internal.LauncherVersion, block.Action)
+	if !internal.NeedsLauncherUpdate(manifest) {
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.blocked_no_update", block.Text()))
+		return
+	}
+	if internal.CheckLauncherDirWritable(launcherPath) != nil {
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.blocked_manual", block.Text(), internal.ManualUpdateHint(launcherPath)))
+		return
+	}
+
+	internal.ShowStyledMessage(internal.Error, block.Text())
+	// RunLauncherUpdateTUI завершает процесс при успехе
+	if err := internal.RunLauncherUpdateTUI(launcherPath); err != nil {
+		internal.ShowExitMessage(internal.Error, internal.T("launcher.update_failed", err))
+	}
+}
+
+// launcherUpdateAccepted решает, обновлять ли лаунчер сейчас: обязательное обновление
+// применяется всегда, необязательное - если пользователь не отложил его
+func launcherUpdateAccepted(manifest *internal.ManifestDto) bool {
+	if internal.IsLauncherUpdateMandatory(manifest) {
+		return true
+	}
+	accepted, err := internal.OfferUpdate(internal.UpdateKindLauncher, internal.LauncherVersion, manifest.Version.Launcher)
+	if err != nil {
+		internal.ShowStyledMessage(internal.Error, internal.T("ui.interface_error", err))
+		return false
+	}
+	return accepted
+}